@@ -12,10 +12,18 @@ var currentCmd = &cobra.Command{
 	Short:         "Current version",
 	SilenceErrors: true,
 	SilenceUsage:  true,
-	Example:       `./version current`,
-	RunE: func(_ *cobra.Command, _ []string) error {
+	Example: `./version current
+./version current --verify`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		verify, err := cmd.Flags().GetBool("verify")
+		if err != nil {
+			return err
+		}
+
 		action := current.New(func(args *current.Args) {
 			args.Repo = di.C.Repo
+			args.Cfg = di.C.Config
+			args.Verify = verify
 		})
 
 		command.Set(action)
@@ -25,5 +33,11 @@ var currentCmd = &cobra.Command{
 }
 
 func init() {
+	initCurrentCmd()
 	rootCmd.AddCommand(currentCmd)
 }
+
+// initCurrentCmd - init current command.
+func initCurrentCmd() {
+	currentCmd.Flags().Bool("verify", false, "verify the last version tag signature")
+}
@@ -22,4 +22,20 @@ func Test_currentCmd(t *testing.T) {
 
 		runnerMock.AssertExpectations(t)
 	})
+
+	t.Run("verify", func(t *testing.T) {
+		t.Cleanup(func() {
+			currentCmd.ResetFlags()
+			initCurrentCmd()
+		})
+
+		runnerMock := __newRunnerMock(nil)
+		command.SetForce(runnerMock)
+
+		rootCmd.SetArgs([]string{currentCmd.Use, "--verify"})
+
+		assert.NoError(t, rootCmd.Execute(), "currentCmd() --verify")
+
+		runnerMock.AssertExpectations(t)
+	})
 }
@@ -14,6 +14,7 @@ var generateCmd = &cobra.Command{
 	SilenceErrors: true,
 	SilenceUsage:  true,
 	Example: `./version generate --config-file
+./version generate --config-file --templates
 ./version generate --changelog`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		actionType := generate.FileUnknown
@@ -36,10 +37,17 @@ var generateCmd = &cobra.Command{
 			return cmd.Help()
 		}
 
+		templates, err := cmd.Flags().GetBool("templates")
+		if err != nil {
+			return err
+		}
+
 		action := generate.New(func(args *generate.Args) {
 			args.ActionType = actionType
 			args.CfgGenerator = di.C.Config
 			args.ChangelogGen = di.C.ChangelogGenerator
+			args.TemplateGen = di.C.ChangelogGenerator
+			args.Templates = templates
 		})
 
 		command.Set(action)
@@ -58,4 +66,5 @@ func init() {
 func initGenerateCmd() {
 	generateCmd.Flags().Bool(generate.FileConfig.String(), false, "generate config file")
 	generateCmd.Flags().Bool(generate.FileChangelog.String(), false, "generate changelog file")
+	generateCmd.Flags().Bool("templates", false, "dump default changelog templates (with --config-file)")
 }
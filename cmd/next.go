@@ -20,12 +20,17 @@ var nextCmd = &cobra.Command{
 	Example: `./version next --major
 ./version next --minor
 ./version next --patch
+./version next --auto
+./version next --minor --pre=rc
+./version next --release
+./version next --patch --build='sha.{{.ShortHash}}'
+./version next --project=backend --minor
 ./version next --ver=1.2.3`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		actionType := next.ActionUnknown
 		v := version.V("")
 
-		flags := []next.ActionType{next.ActionMajor, next.ActionMinor, next.ActionPatch}
+		flags := []next.ActionType{next.ActionMajor, next.ActionMinor, next.ActionPatch, next.ActionAuto, next.ActionRelease}
 
 		for _, f := range flags {
 			b, err := cmd.Flags().GetBool(f.String())
@@ -55,13 +60,26 @@ var nextCmd = &cobra.Command{
 			return cmd.Help()
 		}
 
+		pre, err := cmd.Flags().GetString("pre")
+		if err != nil {
+			return err
+		}
+
+		build, err := cmd.Flags().GetString("build")
+		if err != nil {
+			return err
+		}
+
 		action := next.New(func(args *next.Args) {
 			args.Repo = di.C.Repo
 			args.ChangelogGen = di.C.ChangelogGenerator
 			args.Cfg = di.C.Config
 			args.Bump = di.C.Bump
+			args.Journal = di.C.Journal
 			args.ActionType = actionType
 			args.Version = v
+			args.Pre = pre
+			args.Build = build
 		})
 
 		command.Set(action)
@@ -81,9 +99,24 @@ func initNextCmd() {
 	nextCmd.Flags().Bool(next.ActionMajor.String(), false, "next major version")
 	nextCmd.Flags().Bool(next.ActionMinor.String(), false, "next minor version")
 	nextCmd.Flags().Bool(next.ActionPatch.String(), false, "next patch version")
+	nextCmd.Flags().Bool(next.ActionAuto.String(), false, "next version, calculated from commits since the last version")
+
+	nextCmd.Flags().Bool(next.ActionRelease.String(), false, "release of the current pre-release version")
 
 	nextCmd.Flags().String(next.ActionCustom.String(), "", "next build version in format 1.2.3")
 
+	nextCmd.Flags().String("pre", "", "next pre-release version with identifier (alpha, beta, rc)")
+
+	nextCmd.Flags().String("build", "", "build metadata for version in bump files (template, for example sha.{{.ShortHash}})")
+
+	nextCmd.Flags().String("project", "", "sub-project name from config (monorepo)")
+
+	if err := viper.BindPFlag(key.Project, nextCmd.Flags().Lookup("project")); err != nil {
+		viper.Set(key.Project, "")
+	}
+
+	viper.SetDefault(key.Project, "")
+
 	nextCmd.Flags().Bool("prepare", false, "run only bump files and commands before")
 
 	if err := viper.BindPFlag(key.Prepare, nextCmd.Flags().Lookup("prepare")); err != nil {
@@ -92,6 +125,14 @@ func initNextCmd() {
 
 	viper.SetDefault(key.Prepare, false)
 
+	nextCmd.Flags().Bool("push", false, "push the current branch and the release tag to the remote")
+
+	if err := viper.BindPFlag(key.GitPush, nextCmd.Flags().Lookup("push")); err != nil {
+		viper.Set(key.GitPush, false)
+	}
+
+	viper.SetDefault(key.GitPush, false)
+
 	nextCmd.Flags().BoolP("backup", "b", false, "backup changed files")
 
 	if err := viper.BindPFlag(key.Backup, nextCmd.Flags().Lookup("backup")); err != nil {
@@ -57,6 +57,22 @@ func Test_nextCmd(t *testing.T) {
 			},
 			assertion: assert.NoError,
 		},
+		{
+			name: "call auto",
+			arg:  "--auto",
+			wantCall: wantCall{
+				action: true,
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "call release",
+			arg:  "--release",
+			wantCall: wantCall{
+				action: true,
+			},
+			assertion: assert.NoError,
+		},
 		{
 			name: "call custom",
 			arg:  "--ver=1.2.3",
@@ -125,6 +141,7 @@ func Test_nextCmdFlags(t *testing.T) {
 		prepare bool
 		backup  bool
 		force   bool
+		push    bool
 	}
 
 	tests := []struct {
@@ -149,6 +166,14 @@ func Test_nextCmdFlags(t *testing.T) {
 			},
 			assertion: assert.NoError,
 		},
+		{
+			name: "call push",
+			arg:  "--push",
+			want: want{
+				push: true,
+			},
+			assertion: assert.NoError,
+		},
 		{
 			name: "call force",
 			arg:  "--force",
@@ -176,6 +201,7 @@ func Test_nextCmdFlags(t *testing.T) {
 			assert.Equal(t, tt.want.prepare, viper.GetBool(key.Prepare), "prepare flag not set")
 			assert.Equal(t, tt.want.backup, viper.GetBool(key.Backup), "backup flag not set")
 			assert.Equal(t, tt.want.force, viper.GetBool(key.Force), "force flag not set")
+			assert.Equal(t, tt.want.push, viper.GetBool(key.GitPush), "push flag not set")
 
 		})
 	}
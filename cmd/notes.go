@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/klimby/version/internal/action/notes"
+	"github.com/klimby/version/internal/di"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// notesCmd represents the release-notes command.
+var notesCmd = &cobra.Command{
+	Use:   "release-notes",
+	Short: "Print release notes",
+	Long: `Print release notes (changelog section) for one version to stdout or file.
+Changelog file is not changed. Use --silent flag for print notes only.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Example: `./version release-notes --last
+./version release-notes --ver=1.2.3
+./version release-notes --unreleased
+./version release-notes -s --last > notes.md
+./version release-notes --last --output=notes.md`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ver, err := cmd.Flags().GetString("ver")
+		if err != nil {
+			return err
+		}
+
+		unreleased, err := cmd.Flags().GetBool("unreleased")
+		if err != nil {
+			return err
+		}
+
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return err
+		}
+
+		action := notes.New(func(args *notes.Args) {
+			args.NotesGen = di.C.ChangelogGenerator
+			args.Version = version.V(ver)
+			args.Unreleased = unreleased
+			args.Output = fsys.File(output)
+		})
+
+		command.Set(action)
+
+		return command.Run()
+	},
+}
+
+// init - init release-notes command.
+func init() {
+	initNotesCmd()
+	rootCmd.AddCommand(notesCmd)
+}
+
+// initNotesCmd - init release-notes command.
+func initNotesCmd() {
+	notesCmd.Flags().String("ver", "", "version in format 1.2.3")
+	notesCmd.Flags().Bool("last", false, "last version (default)")
+	notesCmd.Flags().Bool("unreleased", false, "commits since the last version")
+	notesCmd.Flags().StringP("output", "o", "", "output file, default - stdout")
+
+	notesCmd.MarkFlagsMutuallyExclusive("ver", "last", "unreleased")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_notesCmd(t *testing.T) {
+	config.Init(func(options *config.Options) {
+		options.TestingSkipDIInit = true
+	})
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantCall  bool
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "call last",
+			args:      []string{"--last"},
+			wantCall:  true,
+			assertion: assert.NoError,
+		},
+		{
+			name:      "call without args",
+			wantCall:  true,
+			assertion: assert.NoError,
+		},
+		{
+			name:      "call version",
+			args:      []string{"--ver=1.2.3"},
+			wantCall:  true,
+			assertion: assert.NoError,
+		},
+		{
+			name:      "call unreleased",
+			args:      []string{"--unreleased"},
+			wantCall:  true,
+			assertion: assert.NoError,
+		},
+		{
+			name:      "incompatible flags",
+			args:      []string{"--ver=1.2.3", "--unreleased"},
+			wantCall:  false,
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				notesCmd.ResetFlags()
+				initNotesCmd()
+			})
+
+			runnerMock := __newRunnerMock(nil)
+			command.SetForce(runnerMock)
+
+			rootCmd.SetArgs(append([]string{notesCmd.Use}, tt.args...))
+
+			tt.assertion(t, rootCmd.Execute(), "notesCmd()")
+
+			if tt.wantCall {
+				runnerMock.AssertCalled(t, "Run")
+			} else {
+				runnerMock.AssertNotCalled(t, "Run")
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/klimby/version/internal/action/undo"
+	"github.com/klimby/version/internal/di"
+	"github.com/spf13/cobra"
+)
+
+// undoCmd represents the undo command.
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last release",
+	Long: `Undo the last release: delete the release tag and soft-reset the release commit.
+HEAD must be the not pushed release commit, created by the next command.
+Use --restore flag for restore changelog and bump files from the previous commit.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Example: `./version undo
+./version undo --restore
+./version undo -d`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		restore, err := cmd.Flags().GetBool("restore")
+		if err != nil {
+			return err
+		}
+
+		action := undo.New(func(args *undo.Args) {
+			args.Repo = di.C.Repo
+			args.Cfg = di.C.Config
+			args.Restore = restore
+		})
+
+		command.Set(action)
+
+		return command.Run()
+	},
+}
+
+// init - init undo command.
+func init() {
+	initUndoCmd()
+	rootCmd.AddCommand(undoCmd)
+}
+
+// initUndoCmd - init undo command.
+func initUndoCmd() {
+	undoCmd.Flags().Bool("restore", false, "restore changelog and bump files from the previous commit")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_undoCmd(t *testing.T) {
+	config.Init(func(options *config.Options) {
+		options.TestingSkipDIInit = true
+	})
+
+	tests := []struct {
+		name      string
+		args      []string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "undo",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "undo restore",
+			args:      []string{"--restore"},
+			assertion: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				undoCmd.ResetFlags()
+				initUndoCmd()
+			})
+
+			runnerMock := __newRunnerMock(nil)
+			command.SetForce(runnerMock)
+
+			rootCmd.SetArgs(append([]string{undoCmd.Use}, tt.args...))
+
+			tt.assertion(t, rootCmd.Execute(), "undoCmd()")
+
+			runnerMock.AssertCalled(t, "Run")
+		})
+	}
+}
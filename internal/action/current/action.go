@@ -4,24 +4,39 @@ package current
 import (
 	"fmt"
 
+	"github.com/klimby/version/internal/config"
 	"github.com/klimby/version/internal/service/console"
+	"github.com/klimby/version/internal/service/git"
 	"github.com/klimby/version/internal/types"
 	"github.com/klimby/version/pkg/version"
 )
 
 // Action - current action.
 type Action struct {
-	repo actionRepo
+	repo   actionRepo
+	cfg    actionCfg
+	verify bool
 }
 
 // actionRepo - repo interface.
 type actionRepo interface {
 	Current() (version.V, error)
+	CurrentFor(f git.TagFormat) (version.V, error)
+	VerifyTag() (git.TagVerification, error)
+}
+
+// actionCfg - config interface.
+type actionCfg interface {
+	Projects() []config.Project
 }
 
 // Args - action arguments.
 type Args struct {
 	Repo actionRepo
+	// Cfg is a config for sub-projects versions. Optional.
+	Cfg actionCfg
+	// Verify the last version tag signature.
+	Verify bool
 }
 
 // New creates new action.
@@ -33,7 +48,9 @@ func New(args ...func(arg *Args)) *Action {
 	}
 
 	return &Action{
-		repo: a.Repo,
+		repo:   a.Repo,
+		cfg:    a.Cfg,
+		verify: a.Verify,
 	}
 }
 
@@ -50,6 +67,33 @@ func (a Action) Run() error {
 
 	console.Notice(fmt.Sprintf("Current version: %s", v.FormatString()))
 
+	if a.verify {
+		res, err := a.repo.VerifyTag()
+		if err != nil {
+			return err
+		}
+
+		console.Success(fmt.Sprintf("Tag %s has a valid %s signature, key %s", res.Tag, res.Format.String(), res.Key))
+	}
+
+	if a.cfg == nil {
+		return nil
+	}
+
+	for _, p := range a.cfg.Projects() {
+		f, err := p.TagFormat()
+		if err != nil {
+			return err
+		}
+
+		pv, err := a.repo.CurrentFor(f)
+		if err != nil {
+			return err
+		}
+
+		console.Notice(fmt.Sprintf("Project %s version: %s", p.Name, pv.FormatString()))
+	}
+
 	return nil
 }
 
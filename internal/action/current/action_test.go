@@ -3,29 +3,53 @@ package current
 import (
 	"testing"
 
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/git"
 	"github.com/klimby/version/pkg/version"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestAction_Run(t *testing.T) {
-	const ver = version.V("1.2.4")
+	const (
+		ver        = version.V("1.2.4")
+		projectVer = version.V("0.3.0")
+	)
 
 	type fields struct {
-		repo *__repoMock
+		repo   *__repoMock
+		cfg    *__cfgMock
+		verify bool
 	}
 
-	repoMock := func(e error) *__repoMock {
+	repoMock := func(e, projectErr error) *__repoMock {
 		repo := &__repoMock{}
 		repo.On("Current").Return(ver, e)
+		repo.On("CurrentFor", mock.Anything).Return(projectVer, projectErr)
+		repo.On("VerifyTag").Return(git.TagVerification{Tag: "v1.2.4", Format: git.SignSSH, Key: "SHA256:key"}, nil)
 		return repo
 	}
 
+	verifyErrMock := func() *__repoMock {
+		repo := &__repoMock{}
+		repo.On("Current").Return(ver, nil)
+		repo.On("VerifyTag").Return(git.TagVerification{}, git.ErrSignature)
+		return repo
+	}
+
+	cfgMock := func(projects ...config.Project) *__cfgMock {
+		c := &__cfgMock{}
+		c.On("Projects").Return(projects)
+		return c
+	}
+
 	tests := []struct {
-		name      string
-		fields    fields
-		wantCall  bool
-		assertion assert.ErrorAssertionFunc
+		name            string
+		fields          fields
+		wantCall        bool
+		wantProjectCall bool
+		wantVerifyCall  bool
+		assertion       assert.ErrorAssertionFunc
 	}{
 		{
 			name:      "validate error",
@@ -35,7 +59,7 @@ func TestAction_Run(t *testing.T) {
 		{
 			name: "repo error",
 			fields: fields{
-				repo: repoMock(assert.AnError),
+				repo: repoMock(assert.AnError, nil),
 			},
 			wantCall:  true,
 			assertion: assert.Error,
@@ -43,11 +67,69 @@ func TestAction_Run(t *testing.T) {
 		{
 			name: "repo ok",
 			fields: fields{
-				repo: repoMock(nil),
+				repo: repoMock(nil, nil),
+			},
+			wantCall:  true,
+			assertion: assert.NoError,
+		},
+		{
+			name: "verify",
+			fields: fields{
+				repo:   repoMock(nil, nil),
+				verify: true,
+			},
+			wantCall:       true,
+			wantVerifyCall: true,
+			assertion:      assert.NoError,
+		},
+		{
+			name: "verify error",
+			fields: fields{
+				repo:   verifyErrMock(),
+				verify: true,
+			},
+			wantCall:       true,
+			wantVerifyCall: true,
+			assertion:      assert.Error,
+		},
+		{
+			name: "without projects",
+			fields: fields{
+				repo: repoMock(nil, nil),
+				cfg:  cfgMock(),
 			},
 			wantCall:  true,
 			assertion: assert.NoError,
 		},
+		{
+			name: "projects",
+			fields: fields{
+				repo: repoMock(nil, nil),
+				cfg:  cfgMock(config.Project{Name: "backend", TagPrefix: "backend/v"}),
+			},
+			wantCall:        true,
+			wantProjectCall: true,
+			assertion:       assert.NoError,
+		},
+		{
+			name: "project repo error",
+			fields: fields{
+				repo: repoMock(nil, assert.AnError),
+				cfg:  cfgMock(config.Project{Name: "backend", TagPrefix: "backend/v"}),
+			},
+			wantCall:        true,
+			wantProjectCall: true,
+			assertion:       assert.Error,
+		},
+		{
+			name: "project tag format error",
+			fields: fields{
+				repo: repoMock(nil, nil),
+				cfg:  cfgMock(config.Project{Name: "backend", TagTemplate: "backend"}),
+			},
+			wantCall:  true,
+			assertion: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -55,16 +137,35 @@ func TestAction_Run(t *testing.T) {
 				if tt.fields.repo != nil {
 					args.Repo = tt.fields.repo
 				}
+
+				if tt.fields.cfg != nil {
+					args.Cfg = tt.fields.cfg
+				}
+
+				args.Verify = tt.fields.verify
 			})
 
 			tt.assertion(t, a.Run(), tt.name)
 
 			if tt.fields.repo != nil {
 				if tt.wantCall {
-					tt.fields.repo.AssertExpectations(t)
+					tt.fields.repo.AssertCalled(t, "Current")
 				} else {
 					tt.fields.repo.AssertNotCalled(t, "Current")
 				}
+
+				if tt.wantProjectCall {
+					f, _ := git.NewTagFormat("backend/v", "")
+					tt.fields.repo.AssertCalled(t, "CurrentFor", f)
+				} else {
+					tt.fields.repo.AssertNotCalled(t, "CurrentFor", mock.Anything)
+				}
+
+				if tt.wantVerifyCall {
+					tt.fields.repo.AssertCalled(t, "VerifyTag")
+				} else {
+					tt.fields.repo.AssertNotCalled(t, "VerifyTag")
+				}
 			}
 		})
 	}
@@ -78,3 +179,22 @@ func (m *__repoMock) Current() (version.V, error) {
 	args := m.Called()
 	return args.Get(0).(version.V), args.Error(1)
 }
+
+func (m *__repoMock) CurrentFor(f git.TagFormat) (version.V, error) {
+	args := m.Called(f)
+	return args.Get(0).(version.V), args.Error(1)
+}
+
+func (m *__repoMock) VerifyTag() (git.TagVerification, error) {
+	args := m.Called()
+	return args.Get(0).(git.TagVerification), args.Error(1)
+}
+
+type __cfgMock struct {
+	mock.Mock
+}
+
+func (m *__cfgMock) Projects() []config.Project {
+	args := m.Called()
+	return args.Get(0).([]config.Project)
+}
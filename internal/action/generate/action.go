@@ -33,8 +33,10 @@ const (
 type Action struct {
 	cfgGen       generator
 	changelogGen generator
+	templateGen  templateGenerator
 	backup       backupService
 	actionType   ActionType
+	templates    bool
 }
 
 // generator - config generator interface.
@@ -42,6 +44,11 @@ type generator interface {
 	Generate() error
 }
 
+// templateGenerator - changelog templates generator interface.
+type templateGenerator interface {
+	GenerateTemplates() error
+}
+
 // backupService - backup service interface.
 type backupService interface {
 	Create(path string) error
@@ -53,6 +60,10 @@ type Args struct {
 	ChangelogGen generator
 	Backup       backupService
 	ActionType   ActionType
+	// TemplateGen is a changelog templates generator. Required, if Templates is true.
+	TemplateGen templateGenerator
+	// Templates is a flag for dump default changelog templates with config file.
+	Templates bool
 }
 
 // New creates new Action.
@@ -69,8 +80,10 @@ func New(args ...func(arg *Args)) *Action {
 	return &Action{
 		cfgGen:       a.CfgGenerator,
 		changelogGen: a.ChangelogGen,
+		templateGen:  a.TemplateGen,
 		backup:       a.Backup,
 		actionType:   a.ActionType,
+		templates:    a.Templates,
 	}
 }
 
@@ -100,6 +113,16 @@ func (a Action) validate() error {
 		return fmt.Errorf("%w: changelog generator is nil in generate", types.ErrInvalidArguments)
 	}
 
+	if a.templates {
+		if a.actionType != FileConfig {
+			return fmt.Errorf("%w: templates are generated only with config file", types.ErrInvalidArguments)
+		}
+
+		if a.templateGen == nil {
+			return fmt.Errorf("%w: template generator is nil in generate", types.ErrInvalidArguments)
+		}
+	}
+
 	return nil
 }
 
@@ -113,6 +136,12 @@ func (a Action) config() error {
 		return err
 	}
 
+	if a.templates {
+		if err := a.templateGen.GenerateTemplates(); err != nil {
+			return err
+		}
+	}
+
 	if err := a.cfgGen.Generate(); err != nil {
 		return err
 	}
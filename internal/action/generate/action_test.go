@@ -124,7 +124,10 @@ func TestAction_Run(t *testing.T) {
 
 func TestAction_validate(t *testing.T) {
 	type fields struct {
-		actionType ActionType
+		actionType  ActionType
+		cfgGen      *__generatorMock
+		templateGen *__templateGenMock
+		templates   bool
 	}
 
 	tests := []struct {
@@ -153,12 +156,49 @@ func TestAction_validate(t *testing.T) {
 			},
 			assertion: assert.NoError,
 		},
+		{
+			name: "templates without config",
+			fields: fields{
+				actionType:  FileChangelog,
+				templateGen: &__templateGenMock{},
+				templates:   true,
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "templates generator error",
+			fields: fields{
+				actionType: FileConfig,
+				cfgGen:     &__generatorMock{},
+				templates:  true,
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "templates ok",
+			fields: fields{
+				actionType:  FileConfig,
+				cfgGen:      &__generatorMock{},
+				templateGen: &__templateGenMock{},
+				templates:   true,
+			},
+			assertion: assert.NoError,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := New(func(args *Args) {
 				args.ActionType = tt.fields.actionType
+				args.Templates = tt.fields.templates
+
+				if tt.fields.cfgGen != nil {
+					args.CfgGenerator = tt.fields.cfgGen
+				}
+
+				if tt.fields.templateGen != nil {
+					args.TemplateGen = tt.fields.templateGen
+				}
 			})
 
 			tt.assertion(t, a.validate(), "validate()")
@@ -168,8 +208,9 @@ func TestAction_validate(t *testing.T) {
 
 func TestAction_config(t *testing.T) {
 	type fields struct {
-		backup *__backupServiceMock
-		cfg    *__generatorMock
+		backup      *__backupServiceMock
+		cfg         *__generatorMock
+		templateGen *__templateGenMock
 	}
 
 	generatorMock := func(e error) *__generatorMock {
@@ -184,9 +225,16 @@ func TestAction_config(t *testing.T) {
 		return backup
 	}
 
+	templateGenMock := func(e error) *__templateGenMock {
+		gen := &__templateGenMock{}
+		gen.On("GenerateTemplates").Return(e)
+		return gen
+	}
+
 	type wantCall struct {
-		config bool
-		backup bool
+		config    bool
+		backup    bool
+		templates bool
 	}
 
 	tests := []struct {
@@ -231,6 +279,34 @@ func TestAction_config(t *testing.T) {
 			},
 			assertion: assert.Error,
 		},
+		{
+			name: "templates ok",
+			fields: fields{
+				backup:      backupServiceMock(nil),
+				cfg:         generatorMock(nil),
+				templateGen: templateGenMock(nil),
+			},
+			wantCall: wantCall{
+				config:    true,
+				backup:    true,
+				templates: true,
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "templates err",
+			fields: fields{
+				backup:      backupServiceMock(nil),
+				cfg:         generatorMock(nil),
+				templateGen: templateGenMock(assert.AnError),
+			},
+			wantCall: wantCall{
+				config:    false,
+				backup:    true,
+				templates: true,
+			},
+			assertion: assert.Error,
+		},
 	}
 
 	for _, tt := range tests {
@@ -240,6 +316,11 @@ func TestAction_config(t *testing.T) {
 			a := New(func(args *Args) {
 				args.Backup = tt.fields.backup
 				args.CfgGenerator = tt.fields.cfg
+
+				if tt.fields.templateGen != nil {
+					args.TemplateGen = tt.fields.templateGen
+					args.Templates = true
+				}
 			})
 
 			tt.assertion(t, a.config(), "config()")
@@ -259,6 +340,10 @@ func TestAction_config(t *testing.T) {
 					tt.fields.backup.AssertNotCalled(t, "Create")
 				}
 			}
+
+			if tt.fields.templateGen != nil && tt.wantCall.templates {
+				tt.fields.templateGen.AssertExpectations(t)
+			}
 		})
 
 	}
@@ -327,6 +412,15 @@ func (m *__generatorMock) Generate() error {
 	return args.Error(0)
 }
 
+type __templateGenMock struct {
+	mock.Mock
+}
+
+func (m *__templateGenMock) GenerateTemplates() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
 type __backupServiceMock struct {
 	mock.Mock
 }
@@ -4,9 +4,14 @@ package next
 import (
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/klimby/version/internal/config"
 	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/bump"
 	"github.com/klimby/version/internal/service/changelog"
 	"github.com/klimby/version/internal/service/console"
 	"github.com/klimby/version/internal/service/git"
@@ -34,6 +39,8 @@ func (a ActionType) gitNextType() git.NextType {
 		return git.NextPatch
 	case ActionCustom:
 		return git.NextCustom
+	case ActionRelease:
+		return git.NextRelease
 	default:
 		return git.NextNone
 	}
@@ -46,6 +53,8 @@ const (
 	ActionMinor   ActionType = "minor"   // ActionMinor is next minor version.
 	ActionPatch   ActionType = "patch"   // ActionPatch is next patch version.
 	ActionCustom  ActionType = "ver"     // ActionCustom is custom version. Requires custom version.
+	ActionAuto    ActionType = "auto"    // ActionAuto is next version, calculated from commits since the last tag.
+	ActionRelease ActionType = "release" // ActionRelease is release of the current pre-release version.
 )
 
 // Action - next action.
@@ -56,21 +65,30 @@ type Action struct {
 	cfg           actionCfg
 	bump          actionBump
 	cmd           actionCmd
+	journal       actionJournal
 	customVersion version.V
+	pre           string
+	build         string
 }
 
 // actionRepo - repo interface for nextArgs.
 type actionRepo interface {
 	IsClean() (bool, error)
-	NextVersion(nt git.NextType, custom version.V) (version.V, bool, error)
+	NextVersion(nt git.NextType, custom version.V, pre string) (version.V, bool, error)
 	CheckDowngrade(v version.V) error
-	CommitTag(v version.V) error
+	HeadHash() (string, error)
+	CommitTag(v version.V, notes string) error
 	AddModified() error
+	Savepoint() (git.Savepoint, error)
+	Rollback(sp git.Savepoint) error
+	Push(v version.V) error
 }
 
 // actionChGen - changelog interface for nextArgs.
 type actionChGen interface {
 	Add(v version.V) error
+	NextLevel() (changelog.Level, error)
+	ReleaseNotes(wr io.Writer, opts ...func(*changelog.NotesArgs)) error
 }
 
 // actionCfg - config interface for nextArgs.
@@ -82,7 +100,7 @@ type actionCfg interface {
 
 // actionBump - bump interface for nextArgs.
 type actionBump interface {
-	Apply(bumps []config.BumpFile, v version.V)
+	Apply(bumps []config.BumpFile, v version.V) bump.Results
 }
 
 // actionCmd - cmd interface for nextArgs.
@@ -90,6 +108,12 @@ type actionCmd interface {
 	Run(name string, arg ...string) error
 }
 
+// actionJournal - changed files journal interface for nextArgs.
+type actionJournal interface {
+	Changed() bool
+	Rollback() error
+}
+
 // Args - arguments for Next.
 type Args struct {
 	Repo         actionRepo
@@ -97,8 +121,15 @@ type Args struct {
 	Cfg          actionCfg
 	Bump         actionBump
 	Cmd          actionCmd
-	ActionType   ActionType
-	Version      version.V
+	// Journal records files, changed by bump and changelog, for rollback on error.
+	Journal    actionJournal
+	ActionType ActionType
+	Version    version.V
+	// Pre is a pre-release identifier (alpha, beta, rc). Optional.
+	Pre string
+	// Build is a build metadata template for bump files. Optional.
+	// If empty, then will be used build metadata from custom version or config.
+	Build string
 }
 
 // New creates new Action.
@@ -118,34 +149,79 @@ func New(args ...func(arg *Args)) *Action {
 		cfg:           a.Cfg,
 		bump:          a.Bump,
 		cmd:           a.Cmd,
+		journal:       a.Journal,
 		actionType:    a.ActionType,
 		customVersion: a.Version,
+		pre:           a.Pre,
+		build:         a.Build,
 	}
 }
 
 // Run action.
+// On error changed files are restored, the release commit and tag are removed.
+// Push error does not roll back the release.
 func (a Action) Run() error {
 	if err := a.validate(); err != nil {
 		return err
 	}
 
-	nextV, err := a.prepare()
+	if project := viper.GetString(key.Project); project != "" {
+		console.Notice(fmt.Sprintf("Project: %s", project))
+	}
+
+	sp, err := a.repo.Savepoint()
 	if err != nil {
 		return err
 	}
 
+	nextV, err := a.run()
+	if err != nil {
+		a.rollback(sp)
+
+		return err
+	}
+
+	if viper.GetBool(key.Prepare) || !viper.GetBool(key.GitPush) {
+		return nil
+	}
+
+	return a.push(nextV)
+}
+
+// run prepares and applies the next version.
+func (a Action) run() (version.V, error) {
+	nextV, err := a.prepare()
+	if err != nil {
+		return nextV, err
+	}
+
 	if viper.GetBool(key.Prepare) {
 		console.Success(fmt.Sprintf("Prepare complete, next version is %s", nextV.FormatString()))
 
-		return nil
+		return nextV, nil
 	}
 
 	if err := a.apply(nextV); err != nil {
-		return err
+		return nextV, err
 	}
 
 	console.Success(fmt.Sprintf("Version set to %s.", nextV.FormatString()))
 
+	return nextV, nil
+}
+
+// push pushes the release commit and tag to the remote.
+func (a Action) push(nextV version.V) error {
+	remote := viper.GetString(key.GitPushRemote)
+
+	console.Notice(fmt.Sprintf("Push release %s to %s...", nextV.FormatString(), remote))
+
+	if err := a.repo.Push(nextV); err != nil {
+		return fmt.Errorf("%w (release commit and tag are created, push them manually: git push %s --follow-tags)", err, remote)
+	}
+
+	console.Success(fmt.Sprintf("Release %s is pushed to %s.", nextV.FormatString(), remote))
+
 	return nil
 }
 
@@ -166,7 +242,14 @@ func (a Action) prepare() (version.V, error) {
 		return nextV, err
 	}
 
-	a.bump.Apply(a.cfg.BumpFiles(), nextV)
+	bumpV, err := a.bumpVersion(nextV)
+	if err != nil {
+		return nextV, err
+	}
+
+	if err := a.bump.Apply(a.cfg.BumpFiles(), bumpV).Err(); err != nil {
+		return nextV, err
+	}
 
 	if err := a.runCommands(a.cfg.CommandsBefore(), nextV); err != nil {
 		return nextV, err
@@ -189,13 +272,33 @@ func (a Action) apply(nextV version.V) error {
 		console.Warn(err.Error())
 	}
 
-	if err := a.repo.CommitTag(nextV); err != nil {
+	if err := a.repo.CommitTag(nextV, a.releaseNotes(nextV)); err != nil {
 		return err
 	}
 
 	return a.runCommands(a.cfg.CommandsAfter(), nextV)
 }
 
+// rollback restores changed files and the repository state (HEAD, index and tags) from savepoint.
+// Changes of the commands before and after are not restored.
+func (a Action) rollback(sp git.Savepoint) {
+	if viper.GetBool(key.DryRun) {
+		return
+	}
+
+	changed := a.journal.Changed()
+
+	if err := errors.Join(a.journal.Rollback(), a.repo.Rollback(sp)); err != nil {
+		console.Error(fmt.Sprintf("Rollback error: %s", err.Error()))
+
+		return
+	}
+
+	if changed {
+		console.Warn("Changes are rolled back.")
+	}
+}
+
 // validate action.
 func (a Action) validate() error {
 	if a.actionType == ActionUnknown {
@@ -206,6 +309,16 @@ func (a Action) validate() error {
 		return fmt.Errorf("%w: custom version is empty or invalid", types.ErrInvalidArguments)
 	}
 
+	if a.pre != "" {
+		if a.actionType == ActionCustom || a.actionType == ActionRelease {
+			return fmt.Errorf("%w: pre-release is not allowed with %s", types.ErrInvalidArguments, a.actionType.String())
+		}
+
+		if version.V("0.0.0-" + a.pre).Invalid() {
+			return fmt.Errorf("%w: pre-release identifier %s is invalid", types.ErrInvalidArguments, a.pre)
+		}
+	}
+
 	if a.repo == nil {
 		return fmt.Errorf("%w: repo is nil", types.ErrInvalidArguments)
 	}
@@ -226,6 +339,10 @@ func (a Action) validate() error {
 		return fmt.Errorf("%w: cmd is nil", types.ErrInvalidArguments)
 	}
 
+	if a.journal == nil {
+		return fmt.Errorf("%w: journal is nil", types.ErrInvalidArguments)
+	}
+
 	return nil
 }
 
@@ -251,7 +368,12 @@ func (a Action) checkClean() error {
 
 // nextVersion returns the next version.
 func (a Action) nextVersion() (version.V, error) {
-	nextV, exists, err := a.repo.NextVersion(a.actionType.gitNextType(), a.customVersion)
+	nt, err := a.nextType()
+	if err != nil {
+		return "", err
+	}
+
+	nextV, exists, err := a.repo.NextVersion(nt, a.customVersion.WithBuild(""), a.pre)
 	if err != nil {
 		return "", err
 	}
@@ -267,6 +389,118 @@ func (a Action) nextVersion() (version.V, error) {
 	return nextV, nil
 }
 
+// nextType returns the next version type.
+// For auto action it is calculated from commits since the last version.
+// For major, minor and patch actions warns, if commits require another level.
+func (a Action) nextType() (git.NextType, error) {
+	nt := a.actionType.gitNextType()
+
+	if a.actionType == ActionCustom || a.actionType == ActionRelease {
+		return nt, nil
+	}
+
+	level, err := a.changelogGen.NextLevel()
+	if err != nil {
+		if a.actionType != ActionAuto {
+			console.Warn(err.Error())
+
+			return nt, nil
+		}
+
+		return git.NextNone, err
+	}
+
+	if a.actionType == ActionAuto {
+		if level.Type == git.NextNone {
+			return git.NextNone, errors.New("no releasable commits since the last version")
+		}
+
+		console.Notice(fmt.Sprintf("Next version level is %s, because of commits:", level.Type.String()))
+		printCommits(level.Commits)
+
+		return level.Type, nil
+	}
+
+	switch {
+	case level.Type == git.NextNone:
+		console.Warn("No releasable commits since the last version.")
+	case level.Type.Higher(nt):
+		console.Warn(fmt.Sprintf("Next version level is %s, but commits require %s:", nt.String(), level.Type.String()))
+		printCommits(level.Commits)
+	case viper.GetBool(key.Verbose):
+		console.Info(fmt.Sprintf("Commits since the last version require %s level.", level.Type.String()))
+	}
+
+	return nt, nil
+}
+
+// printCommits prints commits, that drove the version level decision.
+func printCommits(cs []git.Commit) {
+	for _, c := range cs {
+		console.Info(c.String())
+	}
+}
+
+// buildData is a data for build metadata template.
+type buildData struct {
+	Hash      string // HEAD commit hash.
+	ShortHash string // HEAD commit short hash.
+	Date      string // Current date in format YYYYMMDD.
+}
+
+// bumpVersion returns the version for bump files: the next version with build metadata.
+// Build metadata is taken from build flag, custom version or config (in this order).
+func (a Action) bumpVersion(nextV version.V) (version.V, error) {
+	tpl := a.build
+
+	if tpl == "" && a.actionType == ActionCustom {
+		tpl = a.customVersion.Build()
+	}
+
+	if tpl == "" {
+		tpl = viper.GetString(key.BuildMetadata)
+	}
+
+	if tpl == "" {
+		return nextV, nil
+	}
+
+	t, err := template.New("build").Parse(tpl)
+	if err != nil {
+		return nextV, fmt.Errorf("%w: build metadata template %s error: %w", types.ErrInvalidArguments, tpl, err)
+	}
+
+	hash, err := a.repo.HeadHash()
+	if err != nil {
+		return nextV, err
+	}
+
+	d := buildData{
+		Hash:      hash,
+		ShortHash: hash,
+		Date:      time.Now().Format("20060102"),
+	}
+
+	if len(hash) > 7 {
+		d.ShortHash = hash[:7]
+	}
+
+	var b strings.Builder
+
+	if err := t.Execute(&b, d); err != nil {
+		return nextV, fmt.Errorf("%w: build metadata template %s error: %w", types.ErrInvalidArguments, tpl, err)
+	}
+
+	bumpV := nextV.WithBuild(b.String())
+	if bumpV.Invalid() {
+		return nextV, fmt.Errorf("%w: build metadata %s is invalid", types.ErrInvalidArguments, b.String())
+	}
+
+	console.Notice(fmt.Sprintf("Version in bump files: %s", bumpV.FormatString()))
+
+	return bumpV, nil
+}
+
 // checkDowngrade checks if the version is not downgraded.
 func (a Action) checkDowngrade(v version.V) error {
 	if err := a.repo.CheckDowngrade(v); err != nil {
@@ -289,6 +523,24 @@ func (a Action) writeChangelog(v version.V) error {
 	return a.changelogGen.Add(v)
 }
 
+// releaseNotes returns release notes of the next version for the release tag message.
+// If notes are not created, then returns empty string.
+func (a Action) releaseNotes(v version.V) string {
+	var b strings.Builder
+
+	if err := a.changelogGen.ReleaseNotes(&b, func(args *changelog.NotesArgs) {
+		args.Next = v
+	}); err != nil {
+		if !errors.Is(err, changelog.ErrWarning) {
+			console.Warn(fmt.Sprintf("Release notes error: %s", err.Error()))
+		}
+
+		return ""
+	}
+
+	return b.String()
+}
+
 // runCommands runs commands.
 func (a Action) runCommands(cs []config.Command, v version.V) error {
 	dryMode := viper.GetBool(key.DryRun)
@@ -1,11 +1,15 @@
 package next
 
 import (
+	"bytes"
+	"io"
 	"testing"
 
 	"github.com/klimby/version/internal/config"
 	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/bump"
 	"github.com/klimby/version/internal/service/changelog"
+	"github.com/klimby/version/internal/service/console"
 	"github.com/klimby/version/internal/service/git"
 	"github.com/klimby/version/pkg/version"
 	"github.com/spf13/viper"
@@ -23,32 +27,46 @@ func TestAction_Run(t *testing.T) {
 		cfg          *__actionCfgMock
 		bump         *__actionBumpMock
 		cmd          *__actionCmdMock
+		journal      *__actionJournalMock
 		prepare      bool
+		push         bool
 	}
 
 	type repoMockArgs struct {
-		isCleanErr, nextVersionErr, checkDowngradeErr, addModifiedErr, commitTagErr error
+		isCleanErr, nextVersionErr, checkDowngradeErr, addModifiedErr, commitTagErr, pushErr error
 	}
 
 	repoMock := func(a repoMockArgs) *__actionRepoMock {
 		r := &__actionRepoMock{}
 		r.On("IsClean").Return(true, a.isCleanErr)
-		r.On("NextVersion", git.NextPatch, mock.Anything).Return(nextVersion, false, a.nextVersionErr)
+		r.On("NextVersion", git.NextPatch, mock.Anything, "").Return(nextVersion, false, a.nextVersionErr)
 		r.On("CheckDowngrade", nextVersion).Return(a.checkDowngradeErr)
 		r.On("AddModified").Return(a.addModifiedErr)
-		r.On("CommitTag", nextVersion).Return(a.commitTagErr)
+		r.On("CommitTag", nextVersion, "## 1.2.4\n").Return(a.commitTagErr)
+		r.On("Savepoint").Return(git.Savepoint{}, nil)
+		r.On("Rollback", mock.Anything).Return(nil)
+		r.On("Push", nextVersion).Return(a.pushErr)
 		return r
 	}
 
 	bumpMock := func() *__actionBumpMock {
 		b := &__actionBumpMock{}
-		b.On("Apply", mock.Anything, nextVersion)
+		b.On("Apply", mock.Anything, nextVersion).Return(bump.Results{})
 		return b
 	}
 
+	journalMock := func() *__actionJournalMock {
+		j := &__actionJournalMock{}
+		j.On("Changed").Return(true)
+		j.On("Rollback").Return(nil)
+		return j
+	}
+
 	changelogMock := func(e error) *__actionChGenMock {
 		c := &__actionChGenMock{}
 		c.On("Add", nextVersion).Return(e)
+		c.On("ReleaseNotes", nextVersion).Return("## 1.2.4\n", nil)
+		c.On("NextLevel").Return(changelog.Level{Type: git.NextPatch}, nil)
 		return c
 	}
 
@@ -80,8 +98,10 @@ func TestAction_Run(t *testing.T) {
 	}
 
 	type wantCalls struct {
-		prepare bool
-		apply   bool
+		prepare  bool
+		apply    bool
+		rollback bool
+		push     bool
 	}
 
 	tests := []struct {
@@ -99,6 +119,7 @@ func TestAction_Run(t *testing.T) {
 				cfg:          cfgMock(),
 				bump:         bumpMock(),
 				cmd:          cmdMock(nil, nil),
+				journal:      journalMock(),
 			},
 			wantCalls: wantCalls{
 				prepare: true,
@@ -115,6 +136,7 @@ func TestAction_Run(t *testing.T) {
 				cfg:          cfgMock(),
 				bump:         bumpMock(),
 				cmd:          cmdMock(nil, nil),
+				journal:      journalMock(),
 				prepare:      true,
 			},
 			wantCalls: wantCalls{
@@ -132,10 +154,12 @@ func TestAction_Run(t *testing.T) {
 				cfg:          cfgMock(),
 				bump:         bumpMock(),
 				cmd:          cmdMock(assert.AnError, nil),
+				journal:      journalMock(),
 			},
 			wantCalls: wantCalls{
-				prepare: true,
-				apply:   false,
+				prepare:  true,
+				apply:    false,
+				rollback: true,
 			},
 			assertion: assert.Error,
 		},
@@ -148,10 +172,87 @@ func TestAction_Run(t *testing.T) {
 				cfg:          cfgMock(),
 				bump:         bumpMock(),
 				cmd:          cmdMock(nil, assert.AnError),
+				journal:      journalMock(),
+			},
+			wantCalls: wantCalls{
+				prepare:  true,
+				apply:    true,
+				rollback: true,
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "push",
+			fields: fields{
+				actionType:   ActionPatch,
+				repo:         repoMock(repoMockArgs{}),
+				changelogGen: changelogMock(nil),
+				cfg:          cfgMock(),
+				bump:         bumpMock(),
+				cmd:          cmdMock(nil, nil),
+				journal:      journalMock(),
+				push:         true,
 			},
 			wantCalls: wantCalls{
 				prepare: true,
 				apply:   true,
+				push:    true,
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "push error",
+			fields: fields{
+				actionType:   ActionPatch,
+				repo:         repoMock(repoMockArgs{pushErr: assert.AnError}),
+				changelogGen: changelogMock(nil),
+				cfg:          cfgMock(),
+				bump:         bumpMock(),
+				cmd:          cmdMock(nil, nil),
+				journal:      journalMock(),
+				push:         true,
+			},
+			wantCalls: wantCalls{
+				prepare: true,
+				apply:   true,
+				push:    true,
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "prepare run with push",
+			fields: fields{
+				actionType:   ActionPatch,
+				repo:         repoMock(repoMockArgs{}),
+				changelogGen: changelogMock(nil),
+				cfg:          cfgMock(),
+				bump:         bumpMock(),
+				cmd:          cmdMock(nil, nil),
+				journal:      journalMock(),
+				prepare:      true,
+				push:         true,
+			},
+			wantCalls: wantCalls{
+				prepare: true,
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "apply error with push",
+			fields: fields{
+				actionType:   ActionPatch,
+				repo:         repoMock(repoMockArgs{}),
+				changelogGen: changelogMock(nil),
+				cfg:          cfgMock(),
+				bump:         bumpMock(),
+				cmd:          cmdMock(nil, assert.AnError),
+				journal:      journalMock(),
+				push:         true,
+			},
+			wantCalls: wantCalls{
+				prepare:  true,
+				apply:    true,
+				rollback: true,
 			},
 			assertion: assert.Error,
 		},
@@ -164,6 +265,7 @@ func TestAction_Run(t *testing.T) {
 				cfg:          cfgMock(),
 				bump:         bumpMock(),
 				cmd:          cmdMock(nil, nil),
+				journal:      journalMock(),
 			},
 			wantCalls: wantCalls{
 				prepare: false,
@@ -177,6 +279,7 @@ func TestAction_Run(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			viper.Set(key.GenerateChangelog, true)
 			viper.Set(key.Prepare, tt.fields.prepare)
+			viper.Set(key.GitPush, tt.fields.push)
 
 			a := New(func(args *Args) {
 				args.ActionType = tt.fields.actionType
@@ -185,6 +288,7 @@ func TestAction_Run(t *testing.T) {
 				args.Cfg = tt.fields.cfg
 				args.Bump = tt.fields.bump
 				args.Cmd = tt.fields.cmd
+				args.Journal = tt.fields.journal
 			})
 
 			tt.assertion(t, a.Run(), "Run() error = %v, wantErr %v", tt.assertion, false)
@@ -201,6 +305,20 @@ func TestAction_Run(t *testing.T) {
 			} else {
 				tt.fields.cmd.AssertNotCalled(t, "Run", "echo", "test-after", "version=1.2.4")
 			}
+
+			if tt.wantCalls.rollback {
+				tt.fields.journal.AssertCalled(t, "Rollback")
+				tt.fields.repo.AssertCalled(t, "Rollback", mock.Anything)
+			} else {
+				tt.fields.journal.AssertNotCalled(t, "Rollback")
+				tt.fields.repo.AssertNotCalled(t, "Rollback", mock.Anything)
+			}
+
+			if tt.wantCalls.push {
+				tt.fields.repo.AssertCalled(t, "Push", nextVersion)
+			} else {
+				tt.fields.repo.AssertNotCalled(t, "Push", mock.Anything)
+			}
 		})
 
 	}
@@ -216,6 +334,12 @@ func TestAction_prepare(t *testing.T) {
 		cmd  *__actionCmdMock
 	}
 
+	changelogMock := func() *__actionChGenMock {
+		c := &__actionChGenMock{}
+		c.On("NextLevel").Return(changelog.Level{Type: git.NextPatch}, nil)
+		return c
+	}
+
 	type repoMockArgs struct {
 		isCleanErr, nextVersionErr, checkDowngradeErr error
 	}
@@ -223,14 +347,14 @@ func TestAction_prepare(t *testing.T) {
 	repoMock := func(a repoMockArgs) *__actionRepoMock {
 		r := &__actionRepoMock{}
 		r.On("IsClean").Return(true, a.isCleanErr)
-		r.On("NextVersion", git.NextPatch, mock.Anything).Return(nextVersion, false, a.nextVersionErr)
+		r.On("NextVersion", git.NextPatch, mock.Anything, "").Return(nextVersion, false, a.nextVersionErr)
 		r.On("CheckDowngrade", nextVersion).Return(a.checkDowngradeErr)
 		return r
 	}
 
-	bumpMock := func() *__actionBumpMock {
+	bumpMock := func(res bump.Results) *__actionBumpMock {
 		b := &__actionBumpMock{}
-		b.On("Apply", mock.Anything, nextVersion)
+		b.On("Apply", mock.Anything, nextVersion).Return(res)
 		return b
 	}
 
@@ -272,7 +396,7 @@ func TestAction_prepare(t *testing.T) {
 			fields: fields{
 				repo: repoMock(repoMockArgs{}),
 				cfg:  cfgMock(),
-				bump: bumpMock(),
+				bump: bumpMock(nil),
 				cmd:  cmdMock(nil),
 			},
 			wantCalls: wantCalls{
@@ -289,7 +413,7 @@ func TestAction_prepare(t *testing.T) {
 			fields: fields{
 				repo: repoMock(repoMockArgs{isCleanErr: assert.AnError}),
 				cfg:  cfgMock(),
-				bump: bumpMock(),
+				bump: bumpMock(nil),
 				cmd:  cmdMock(nil),
 			},
 			wantCalls: wantCalls{
@@ -306,7 +430,7 @@ func TestAction_prepare(t *testing.T) {
 			fields: fields{
 				repo: repoMock(repoMockArgs{nextVersionErr: assert.AnError}),
 				cfg:  cfgMock(),
-				bump: bumpMock(),
+				bump: bumpMock(nil),
 				cmd:  cmdMock(nil),
 			},
 			wantCalls: wantCalls{
@@ -323,7 +447,7 @@ func TestAction_prepare(t *testing.T) {
 			fields: fields{
 				repo: repoMock(repoMockArgs{checkDowngradeErr: assert.AnError}),
 				cfg:  cfgMock(),
-				bump: bumpMock(),
+				bump: bumpMock(nil),
 				cmd:  cmdMock(nil),
 			},
 			wantCalls: wantCalls{
@@ -335,12 +459,29 @@ func TestAction_prepare(t *testing.T) {
 			},
 			assertion: assert.Error,
 		},
+		{
+			name: "required bump file not changed",
+			fields: fields{
+				repo: repoMock(repoMockArgs{}),
+				cfg:  cfgMock(),
+				bump: bumpMock(bump.Results{{File: "package.json", Required: true}}),
+				cmd:  cmdMock(nil),
+			},
+			wantCalls: wantCalls{
+				checkClean:     true,
+				nextVersion:    true,
+				checkDowngrade: true,
+				bump:           true,
+				runCommands:    false,
+			},
+			assertion: assert.Error,
+		},
 		{
 			name: "runCommands error",
 			fields: fields{
 				repo: repoMock(repoMockArgs{}),
 				cfg:  cfgMock(),
-				bump: bumpMock(),
+				bump: bumpMock(nil),
 				cmd:  cmdMock(assert.AnError),
 			},
 			wantCalls: wantCalls{
@@ -364,6 +505,7 @@ func TestAction_prepare(t *testing.T) {
 			a := New(func(args *Args) {
 				args.ActionType = ActionPatch
 				args.Repo = tt.fields.repo
+				args.ChangelogGen = changelogMock()
 				args.Cfg = tt.fields.cfg
 				args.Bump = tt.fields.bump
 				args.Cmd = tt.fields.cmd
@@ -383,7 +525,7 @@ func TestAction_prepare(t *testing.T) {
 			}
 
 			if tt.wantCalls.nextVersion {
-				tt.fields.repo.AssertCalled(t, "NextVersion", git.NextPatch, mock.Anything)
+				tt.fields.repo.AssertCalled(t, "NextVersion", git.NextPatch, mock.Anything, "")
 			} else {
 				tt.fields.repo.AssertNotCalled(t, "NextVersion")
 			}
@@ -436,13 +578,14 @@ func TestAction_apply(t *testing.T) {
 	repoMock := func(addModifiedErr, commitTagErr error) *__actionRepoMock {
 		r := &__actionRepoMock{}
 		r.On("AddModified").Return(addModifiedErr)
-		r.On("CommitTag", nextV).Return(commitTagErr)
+		r.On("CommitTag", nextV, "## 1.2.4\n").Return(commitTagErr)
 		return r
 	}
 
 	changelogMock := func(e error) *__actionChGenMock {
 		c := &__actionChGenMock{}
 		c.On("Add", nextV).Return(e)
+		c.On("ReleaseNotes", nextV).Return("## 1.2.4\n", nil)
 		return c
 	}
 
@@ -590,7 +733,7 @@ func TestAction_apply(t *testing.T) {
 			}
 
 			if tt.wantCalls.repoCommitTag {
-				tt.fields.repo.AssertCalled(t, "CommitTag", nextV)
+				tt.fields.repo.AssertCalled(t, "CommitTag", nextV, "## 1.2.4\n")
 			} else {
 				tt.fields.repo.AssertNotCalled(t, "CommitTag")
 			}
@@ -609,11 +752,13 @@ func TestAction_validate(t *testing.T) {
 	type fields struct {
 		actionType    ActionType
 		customVersion version.V
+		pre           string
 		repo          *__actionRepoMock
 		changelogGen  *__actionChGenMock
 		cfg           *__actionCfgMock
 		bump          *__actionBumpMock
 		cmd           *__actionCmdMock
+		journal       *__actionJournalMock
 	}
 
 	type wantErr struct {
@@ -647,6 +792,29 @@ func TestAction_validate(t *testing.T) {
 				contains: "custom version",
 			},
 		},
+		{
+			name: "pre-release with custom version",
+			fields: fields{
+				actionType:    ActionCustom,
+				customVersion: version.V("1.2.3"),
+				pre:           "rc",
+			},
+			wantErr: wantErr{
+				want:     true,
+				contains: "pre-release is not allowed",
+			},
+		},
+		{
+			name: "invalid pre-release",
+			fields: fields{
+				actionType: ActionMinor,
+				pre:        "rc_1",
+			},
+			wantErr: wantErr{
+				want:     true,
+				contains: "pre-release identifier",
+			},
+		},
 		{
 			name: "nil repo",
 			fields: fields{
@@ -722,6 +890,21 @@ func TestAction_validate(t *testing.T) {
 				contains: "cmd is nil",
 			},
 		},
+		{
+			name: "nil journal",
+			fields: fields{
+				actionType:   ActionPatch,
+				repo:         &__actionRepoMock{},
+				changelogGen: &__actionChGenMock{},
+				cfg:          &__actionCfgMock{},
+				bump:         &__actionBumpMock{},
+				cmd:          &__actionCmdMock{},
+			},
+			wantErr: wantErr{
+				want:     true,
+				contains: "journal is nil",
+			},
+		},
 		{
 			name: "valid",
 			fields: fields{
@@ -731,6 +914,7 @@ func TestAction_validate(t *testing.T) {
 				cfg:          &__actionCfgMock{},
 				bump:         &__actionBumpMock{},
 				cmd:          &__actionCmdMock{},
+				journal:      &__actionJournalMock{},
 			},
 		},
 	}
@@ -740,6 +924,7 @@ func TestAction_validate(t *testing.T) {
 			a := New(func(args *Args) {
 				args.ActionType = tt.fields.actionType
 				args.Version = tt.fields.customVersion
+				args.Pre = tt.fields.pre
 
 				if tt.fields.repo != nil {
 					args.Repo = tt.fields.repo
@@ -757,6 +942,10 @@ func TestAction_validate(t *testing.T) {
 					args.Bump = tt.fields.bump
 				}
 
+				if tt.fields.journal != nil {
+					args.Journal = tt.fields.journal
+				}
+
 				if tt.fields.cmd != nil {
 					args.Cmd = tt.fields.cmd
 				} else {
@@ -877,7 +1066,7 @@ func TestAction_nextVersion(t *testing.T) {
 
 	repoFn := func(exists bool, e error) *__actionRepoMock {
 		r := &__actionRepoMock{}
-		r.On("NextVersion", git.NextPatch, currentVersion).Return(nextVersion, exists, e)
+		r.On("NextVersion", git.NextPatch, currentVersion, "").Return(nextVersion, exists, e)
 		return r
 	}
 
@@ -921,18 +1110,243 @@ func TestAction_nextVersion(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			viper.Set(key.AutoGenerateNextPatch, tt.fields.autoGenerateNextPatch)
 
+			chGen := &__actionChGenMock{}
+			chGen.On("NextLevel").Return(changelog.Level{Type: git.NextPatch}, nil)
+
 			a := &Action{
 				actionType:    ActionPatch,
 				repo:          tt.fields.repo,
+				changelogGen:  chGen,
 				customVersion: currentVersion,
 			}
 
 			_, err := a.nextVersion()
 			tt.assertion(t, err, "nextVersion() error")
 
-			tt.fields.repo.AssertCalled(t, "NextVersion", git.NextPatch, currentVersion)
+			tt.fields.repo.AssertCalled(t, "NextVersion", git.NextPatch, currentVersion, "")
+		})
+
+	}
+}
+
+func TestAction_nextType(t *testing.T) {
+	type fields struct {
+		actionType ActionType
+		level      git.NextType
+		levelErr   error
+	}
+
+	type want struct {
+		nextType git.NextType
+		warning  string
+	}
+
+	tests := []struct {
+		name      string
+		fields    fields
+		want      want
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name: "auto minor",
+			fields: fields{
+				actionType: ActionAuto,
+				level:      git.NextMinor,
+			},
+			want: want{
+				nextType: git.NextMinor,
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "auto no releasable commits",
+			fields: fields{
+				actionType: ActionAuto,
+				level:      git.NextNone,
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "auto next level error",
+			fields: fields{
+				actionType: ActionAuto,
+				level:      git.NextMinor,
+				levelErr:   assert.AnError,
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "patch with feature commits",
+			fields: fields{
+				actionType: ActionPatch,
+				level:      git.NextMinor,
+			},
+			want: want{
+				nextType: git.NextPatch,
+				warning:  "Next version level is patch, but commits require minor",
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "patch without releasable commits",
+			fields: fields{
+				actionType: ActionPatch,
+				level:      git.NextNone,
+			},
+			want: want{
+				nextType: git.NextPatch,
+				warning:  "No releasable commits",
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "major with feature commits",
+			fields: fields{
+				actionType: ActionMajor,
+				level:      git.NextMinor,
+			},
+			want: want{
+				nextType: git.NextMajor,
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "patch next level error",
+			fields: fields{
+				actionType: ActionPatch,
+				levelErr:   assert.AnError,
+			},
+			want: want{
+				nextType: git.NextPatch,
+				warning:  assert.AnError.Error(),
+			},
+			assertion: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdOut := &__consoleWriter{}
+
+			console.Init(func(options *console.OutArgs) {
+				options.Stdout = stdOut
+			})
+
+			chGen := &__actionChGenMock{}
+			chGen.On("NextLevel").Return(changelog.Level{
+				Type: tt.fields.level,
+				Commits: []git.Commit{
+					{Hash: "0123456789", Message: "feat: message"},
+				},
+			}, tt.fields.levelErr)
+
+			a := &Action{
+				actionType:   tt.fields.actionType,
+				changelogGen: chGen,
+			}
+
+			got, err := a.nextType()
+			if !tt.assertion(t, err, "nextType() error") || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want.nextType, got, "nextType()")
+
+			if tt.want.warning != "" {
+				assert.Contains(t, stdOut.String(), tt.want.warning, "nextType() warning")
+			}
 		})
+	}
+}
+
+func TestAction_bumpVersion(t *testing.T) {
+	const (
+		nextVersion = version.V("1.2.4")
+		hash        = "abc1234def5678"
+	)
+
+	type fields struct {
+		actionType    ActionType
+		customVersion version.V
+		build         string
+		cfgBuild      string
+		hashErr       error
+	}
+
+	tests := []struct {
+		name      string
+		fields    fields
+		want      version.V
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "without build",
+			fields:    fields{actionType: ActionPatch},
+			want:      nextVersion,
+			assertion: assert.NoError,
+		},
+		{
+			name:      "build flag",
+			fields:    fields{actionType: ActionPatch, build: "sha.{{.ShortHash}}", cfgBuild: "{{.Hash}}"},
+			want:      "1.2.4+sha.abc1234",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "config build",
+			fields:    fields{actionType: ActionPatch, cfgBuild: "{{.Hash}}"},
+			want:      "1.2.4+abc1234def5678",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "custom version build",
+			fields:    fields{actionType: ActionCustom, customVersion: "1.2.4+exp.1", cfgBuild: "{{.Hash}}"},
+			want:      "1.2.4+exp.1",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "invalid template",
+			fields:    fields{actionType: ActionPatch, build: "{{.ShortHash"},
+			assertion: assert.Error,
+		},
+		{
+			name:      "unknown template field",
+			fields:    fields{actionType: ActionPatch, build: "{{.Unknown}}"},
+			assertion: assert.Error,
+		},
+		{
+			name:      "invalid build metadata",
+			fields:    fields{actionType: ActionPatch, build: "sha_{{.ShortHash}}"},
+			assertion: assert.Error,
+		},
+		{
+			name:      "head hash error",
+			fields:    fields{actionType: ActionPatch, build: "{{.ShortHash}}", hashErr: assert.AnError},
+			assertion: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.BuildMetadata, tt.fields.cfgBuild)
+			defer viper.Set(key.BuildMetadata, "")
+
+			r := &__actionRepoMock{}
+			r.On("HeadHash").Return(hash, tt.fields.hashErr)
+
+			a := &Action{
+				actionType:    tt.fields.actionType,
+				repo:          r,
+				customVersion: tt.fields.customVersion,
+				build:         tt.fields.build,
+			}
 
+			got, err := a.bumpVersion(nextVersion)
+			if !tt.assertion(t, err, "bumpVersion() error") || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want, got, "bumpVersion()")
+		})
 	}
 }
 
@@ -996,6 +1410,45 @@ func TestAction_checkDowngrade(t *testing.T) {
 	}
 }
 
+func TestAction_releaseNotes(t *testing.T) {
+	const v = version.V("1.2.3")
+
+	tests := []struct {
+		name  string
+		notes string
+		err   error
+		want  string
+	}{
+		{
+			name:  "notes",
+			notes: "## 1.2.3\n",
+			want:  "## 1.2.3\n",
+		},
+		{
+			name: "warning",
+			err:  changelog.ErrWarning,
+			want: "",
+		},
+		{
+			name: "error",
+			err:  assert.AnError,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &__actionChGenMock{}
+			c.On("ReleaseNotes", v).Return(tt.notes, tt.err)
+
+			a := Action{changelogGen: c}
+
+			assert.Equal(t, tt.want, a.releaseNotes(v), "releaseNotes()")
+			c.AssertCalled(t, "ReleaseNotes", v)
+		})
+	}
+}
+
 func TestAction_writeChangelog(t *testing.T) {
 	const versionToCheck = version.V("1.2.3")
 
@@ -1178,8 +1631,8 @@ func (m *__actionRepoMock) IsClean() (bool, error) {
 	return r0, r1
 }
 
-func (m *__actionRepoMock) NextVersion(nt git.NextType, custom version.V) (version.V, bool, error) {
-	ret := m.Called(nt, custom)
+func (m *__actionRepoMock) NextVersion(nt git.NextType, custom version.V, pre string) (version.V, bool, error) {
+	ret := m.Called(nt, custom, pre)
 
 	r0 := ret.Get(0).(version.V)
 	r1 := ret.Get(1).(bool)
@@ -1194,8 +1647,14 @@ func (m *__actionRepoMock) CheckDowngrade(v version.V) error {
 	return ret.Error(0)
 }
 
-func (m *__actionRepoMock) CommitTag(v version.V) error {
-	ret := m.Called(v)
+func (m *__actionRepoMock) HeadHash() (string, error) {
+	ret := m.Called()
+
+	return ret.String(0), ret.Error(1)
+}
+
+func (m *__actionRepoMock) CommitTag(v version.V, notes string) error {
+	ret := m.Called(v, notes)
 
 	return ret.Error(0)
 }
@@ -1206,6 +1665,40 @@ func (m *__actionRepoMock) AddModified() error {
 	return ret.Error(0)
 }
 
+func (m *__actionRepoMock) Savepoint() (git.Savepoint, error) {
+	ret := m.Called()
+
+	return ret.Get(0).(git.Savepoint), ret.Error(1)
+}
+
+func (m *__actionRepoMock) Rollback(sp git.Savepoint) error {
+	ret := m.Called(sp)
+
+	return ret.Error(0)
+}
+
+func (m *__actionRepoMock) Push(v version.V) error {
+	ret := m.Called(v)
+
+	return ret.Error(0)
+}
+
+type __actionJournalMock struct {
+	mock.Mock
+}
+
+func (m *__actionJournalMock) Changed() bool {
+	ret := m.Called()
+
+	return ret.Bool(0)
+}
+
+func (m *__actionJournalMock) Rollback() error {
+	ret := m.Called()
+
+	return ret.Error(0)
+}
+
 type __actionChGenMock struct {
 	mock.Mock
 }
@@ -1216,6 +1709,31 @@ func (m *__actionChGenMock) Add(v version.V) error {
 	return ret.Error(0)
 }
 
+func (m *__actionChGenMock) NextLevel() (changelog.Level, error) {
+	ret := m.Called()
+
+	r0 := ret.Get(0).(changelog.Level)
+	r1 := ret.Error(1)
+
+	return r0, r1
+}
+
+func (m *__actionChGenMock) ReleaseNotes(wr io.Writer, opts ...func(*changelog.NotesArgs)) error {
+	a := &changelog.NotesArgs{}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	ret := m.Called(a.Next)
+
+	if ret.Error(1) == nil {
+		_, _ = io.WriteString(wr, ret.String(0))
+	}
+
+	return ret.Error(1)
+}
+
 type __actionCfgMock struct {
 	mock.Mock
 }
@@ -1257,8 +1775,9 @@ type __actionBumpMock struct {
 	mock.Mock
 }
 
-func (m *__actionBumpMock) Apply(bumps []config.BumpFile, v version.V) {
-	m.Called(bumps, v)
+func (m *__actionBumpMock) Apply(bumps []config.BumpFile, v version.V) bump.Results {
+	ret := m.Called(bumps, v)
+	return ret.Get(0).(bump.Results)
 }
 
 type __actionCmdMock struct {
@@ -1275,6 +1794,18 @@ func (m *__actionCmdMock) Run(name string, arg ...string) error {
 	return ret.Error(0)
 }
 
+type __consoleWriter struct {
+	buffer bytes.Buffer
+}
+
+func (n *__consoleWriter) Write(p []byte) (int, error) {
+	return n.buffer.Write(p)
+}
+
+func (n *__consoleWriter) String() string {
+	return n.buffer.String()
+}
+
 func TestActionType_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1320,6 +1851,16 @@ func TestActionType_gitNextType(t *testing.T) {
 			a:    ActionCustom,
 			want: git.NextCustom,
 		},
+		{
+			name: "auto",
+			a:    ActionAuto,
+			want: git.NextNone,
+		},
+		{
+			name: "release",
+			a:    ActionRelease,
+			want: git.NextRelease,
+		},
 		{
 			name: "unknown",
 			a:    ActionUnknown,
@@ -0,0 +1,143 @@
+// Package notes provides release notes action.
+package notes
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/changelog"
+	"github.com/klimby/version/internal/service/console"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/types"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+)
+
+// Action - release notes action.
+type Action struct {
+	notesGen   notesGenerator
+	rw         writer
+	out        io.Writer
+	version    version.V
+	unreleased bool
+	output     fsys.File
+}
+
+// notesGenerator - release notes generator interface.
+type notesGenerator interface {
+	ReleaseNotes(wr io.Writer, opts ...func(*changelog.NotesArgs)) error
+}
+
+// writer - file writer interface.
+type writer interface {
+	Write(patch string, flag int) (io.WriteCloser, error)
+}
+
+// Args - action arguments.
+type Args struct {
+	NotesGen notesGenerator
+	RW       writer
+	// Out is a writer for notes, if Output is empty. Default: stdout.
+	Out io.Writer
+	// Version is a version for notes. If empty, then notes for the last version.
+	Version version.V
+	// Unreleased is a flag for notes from commits since the last version.
+	Unreleased bool
+	// Output is a file for notes. Optional.
+	Output fsys.File
+}
+
+// New creates new action.
+func New(args ...func(arg *Args)) *Action {
+	a := &Args{
+		RW:  fsys.New(),
+		Out: os.Stdout,
+	}
+
+	for _, arg := range args {
+		arg(a)
+	}
+
+	return &Action{
+		notesGen:   a.NotesGen,
+		rw:         a.RW,
+		out:        a.Out,
+		version:    a.Version,
+		unreleased: a.Unreleased,
+		output:     a.Output,
+	}
+}
+
+// Run action.
+func (a Action) Run() error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+
+	if err := a.notesGen.ReleaseNotes(&b, func(args *changelog.NotesArgs) {
+		args.Version = a.version
+		args.Unreleased = a.unreleased
+	}); err != nil {
+		return err
+	}
+
+	if a.output.Empty() {
+		if _, err := io.WriteString(a.out, b.String()); err != nil {
+			return fmt.Errorf("write release notes error: %w", err)
+		}
+
+		return nil
+	}
+
+	if viper.GetBool(key.DryRun) {
+		return nil
+	}
+
+	return a.write(b.String())
+}
+
+// write writes notes to the output file.
+func (a Action) write(notes string) (err error) {
+	w, err := a.rw.Write(a.output.Path(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	if err != nil {
+		return fmt.Errorf("open release notes file error: %w", err)
+	}
+
+	defer func() {
+		if e := w.Close(); e != nil {
+			if err == nil {
+				err = fmt.Errorf("close release notes file error: %w", e)
+			}
+		}
+	}()
+
+	if _, err := io.WriteString(w, notes); err != nil {
+		return fmt.Errorf("write release notes file error: %w", err)
+	}
+
+	console.Success(fmt.Sprintf("Release notes %s created.", a.output.String()))
+
+	return nil
+}
+
+// validate action.
+func (a Action) validate() error {
+	if a.notesGen == nil {
+		return fmt.Errorf("%w: notes generator is nil in release-notes", types.ErrInvalidArguments)
+	}
+
+	if a.version != "" && a.version.Invalid() {
+		return fmt.Errorf("%w: invalid version %s", types.ErrInvalidArguments, a.version)
+	}
+
+	if a.unreleased && a.version != "" {
+		return fmt.Errorf("%w: version and unreleased notes are incompatible", types.ErrInvalidArguments)
+	}
+
+	return nil
+}
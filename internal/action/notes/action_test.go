@@ -0,0 +1,186 @@
+package notes
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/changelog"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAction_Run(t *testing.T) {
+	const notes = "## 1.2.3 (2024-01-02)\n"
+
+	type fields struct {
+		notesGen   *__notesGenMock
+		version    version.V
+		unreleased bool
+		output     fsys.File
+		dryRun     bool
+	}
+
+	notesGenMock := func(e error) *__notesGenMock {
+		gen := &__notesGenMock{notes: notes}
+		gen.On("ReleaseNotes", mock.Anything).Return(e)
+		return gen
+	}
+
+	tests := []struct {
+		name       string
+		fields     fields
+		wantOut    string
+		wantFile   string
+		wantArgs   changelog.NotesArgs
+		assertion  assert.ErrorAssertionFunc
+		wantCalled bool
+	}{
+		{
+			name:      "validate error",
+			fields:    fields{},
+			assertion: assert.Error,
+		},
+		{
+			name: "invalid version",
+			fields: fields{
+				notesGen: notesGenMock(nil),
+				version:  "foo",
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "version and unreleased",
+			fields: fields{
+				notesGen:   notesGenMock(nil),
+				version:    "1.2.3",
+				unreleased: true,
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "generator error",
+			fields: fields{
+				notesGen: notesGenMock(assert.AnError),
+			},
+			wantCalled: true,
+			assertion:  assert.Error,
+		},
+		{
+			name: "stdout",
+			fields: fields{
+				notesGen: notesGenMock(nil),
+				version:  "1.2.3",
+			},
+			wantOut:    notes,
+			wantArgs:   changelog.NotesArgs{Version: "1.2.3"},
+			wantCalled: true,
+			assertion:  assert.NoError,
+		},
+		{
+			name: "file",
+			fields: fields{
+				notesGen:   notesGenMock(nil),
+				unreleased: true,
+				output:     "notes.md",
+			},
+			wantFile:   notes,
+			wantArgs:   changelog.NotesArgs{Unreleased: true},
+			wantCalled: true,
+			assertion:  assert.NoError,
+		},
+		{
+			name: "file dry run",
+			fields: fields{
+				notesGen: notesGenMock(nil),
+				output:   "notes.md",
+				dryRun:   true,
+			},
+			wantCalled: true,
+			assertion:  assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.DryRun, tt.fields.dryRun)
+
+			var (
+				out  strings.Builder
+				file bytes.Buffer
+			)
+
+			a := New(func(args *Args) {
+				if tt.fields.notesGen != nil {
+					args.NotesGen = tt.fields.notesGen
+				}
+
+				args.RW = &__writerMock{buf: &file}
+				args.Out = &out
+				args.Version = tt.fields.version
+				args.Unreleased = tt.fields.unreleased
+				args.Output = tt.fields.output
+			})
+
+			err := a.Run()
+			tt.assertion(t, err, "Run()")
+
+			if tt.fields.notesGen != nil {
+				if tt.wantCalled {
+					tt.fields.notesGen.AssertExpectations(t)
+				} else {
+					tt.fields.notesGen.AssertNotCalled(t, "ReleaseNotes", mock.Anything)
+				}
+			}
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.wantArgs, tt.fields.notesGen.args, "ReleaseNotes() args")
+			assert.Equal(t, tt.wantOut, out.String(), "Run() stdout")
+			assert.Equal(t, tt.wantFile, file.String(), "Run() file")
+		})
+	}
+}
+
+type __notesGenMock struct {
+	mock.Mock
+	notes string
+	args  changelog.NotesArgs
+}
+
+func (m *__notesGenMock) ReleaseNotes(wr io.Writer, opts ...func(*changelog.NotesArgs)) error {
+	for _, opt := range opts {
+		opt(&m.args)
+	}
+
+	ret := m.Called(wr)
+
+	if ret.Error(0) == nil {
+		_, _ = io.WriteString(wr, m.notes)
+	}
+
+	return ret.Error(0)
+}
+
+type __writerMock struct {
+	buf *bytes.Buffer
+}
+
+func (m *__writerMock) Write(string, int) (io.WriteCloser, error) {
+	return __nopWriteCloser{m.buf}, nil
+}
+
+type __nopWriteCloser struct {
+	io.Writer
+}
+
+func (__nopWriteCloser) Close() error {
+	return nil
+}
@@ -95,6 +95,10 @@ func (a Action) makeBackup() {
 
 	for _, bmp := range a.cfg.BumpFiles() {
 		a.remover.Remove(bmp.File.Path())
+
+		for _, lock := range bmp.Lockfiles() {
+			a.remover.Remove(lock.Path())
+		}
 	}
 
 	console.Success("Backup files removed.")
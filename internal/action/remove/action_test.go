@@ -104,6 +104,32 @@ func TestAction_Run(t *testing.T) {
 	}
 }
 
+func TestAction_Run_lockfiles(t *testing.T) {
+	viper.Set(key.CfgFile, "config.yaml")
+
+	remover := &__removerMock{}
+	remover.On("Remove", mock.Anything)
+
+	cfg := &__cfgSrvMock{}
+	cfg.On("BumpFiles").Return([]config.BumpFile{
+		{
+			File: fsys.File("package.json"),
+		},
+	})
+
+	a := New(func(arg *Args) {
+		arg.ActionType = ActionBackup
+		arg.Cfg = cfg
+		arg.Remover = remover
+	})
+
+	assert.NoError(t, a.Run())
+
+	remover.AssertCalled(t, "Remove", "package.json")
+	remover.AssertCalled(t, "Remove", "package-lock.json")
+	remover.AssertCalled(t, "Remove", "npm-shrinkwrap.json")
+}
+
 type __removerMock struct {
 	mock.Mock
 }
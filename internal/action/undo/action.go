@@ -0,0 +1,158 @@
+// Package undo provides undo action: reverts the last release commit and tag.
+package undo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/console"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/klimby/version/internal/types"
+	"github.com/spf13/viper"
+)
+
+// Action - undo action.
+type Action struct {
+	repo    actionRepo
+	cfg     actionCfg
+	restore bool
+}
+
+// actionRepo - repo interface.
+type actionRepo interface {
+	IsClean() (bool, error)
+	LastRelease(projects ...git.TagFormat) (git.Release, error)
+	Undo(rel git.Release, restore bool) error
+}
+
+// actionCfg - config interface.
+type actionCfg interface {
+	Projects() []config.Project
+}
+
+// Args - action arguments.
+type Args struct {
+	Repo actionRepo
+	// Cfg is a config for sub-projects tag formats. Optional.
+	Cfg actionCfg
+	// Restore files of the release commit (changelog and bump files) from the previous commit.
+	Restore bool
+}
+
+// New creates new action.
+func New(args ...func(arg *Args)) *Action {
+	a := &Args{}
+
+	for _, arg := range args {
+		arg(a)
+	}
+
+	return &Action{
+		repo:    a.Repo,
+		cfg:     a.Cfg,
+		restore: a.Restore,
+	}
+}
+
+// Run action.
+func (a Action) Run() error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+
+	formats, err := a.projectFormats()
+	if err != nil {
+		return err
+	}
+
+	rel, err := a.repo.LastRelease(formats...)
+	if err != nil {
+		return err
+	}
+
+	if a.restore {
+		if err := a.checkClean(); err != nil {
+			return err
+		}
+	}
+
+	a.plan(rel)
+
+	if viper.GetBool(key.DryRun) {
+		return nil
+	}
+
+	if err := a.repo.Undo(rel, a.restore); err != nil {
+		return err
+	}
+
+	console.Success(fmt.Sprintf("Release %s is undone.", rel.Version.FormatString()))
+
+	return nil
+}
+
+// projectFormats returns tag formats of sub-projects.
+func (a Action) projectFormats() ([]git.TagFormat, error) {
+	if a.cfg == nil {
+		return nil, nil
+	}
+
+	projects := a.cfg.Projects()
+	formats := make([]git.TagFormat, 0, len(projects))
+
+	for _, p := range projects {
+		f, err := p.TagFormat()
+		if err != nil {
+			return nil, err
+		}
+
+		formats = append(formats, f)
+	}
+
+	return formats, nil
+}
+
+// plan prints what will be undone.
+func (a Action) plan(rel git.Release) {
+	console.Notice(fmt.Sprintf("Delete tag %s", rel.Tag))
+	console.Notice(fmt.Sprintf("Reset %s to %s (soft)", rel.Branch.Short(), rel.Parent.String()[:7]))
+
+	if !a.restore {
+		return
+	}
+
+	for _, f := range rel.Files {
+		console.Notice(fmt.Sprintf("Restore file %s", f))
+	}
+}
+
+// checkClean checks if the repository is clean: restored files overwrite working tree changes.
+func (a Action) checkClean() error {
+	isClean, err := a.repo.IsClean()
+	if err != nil {
+		return err
+	}
+
+	if !isClean {
+		const msg = "repository is not clean"
+
+		if !viper.GetBool(key.AllowCommitDirty) {
+			return errors.New(msg)
+		}
+
+		console.Warn(msg)
+	}
+
+	return nil
+}
+
+// validate action.
+func (a Action) validate() error {
+	if a.repo == nil {
+		return fmt.Errorf("%w: repo is nil in undo", types.ErrInvalidArguments)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package undo
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAction_Run(t *testing.T) {
+	rel := git.Release{
+		Version: version.V("1.2.4"),
+		Tag:     "v1.2.4",
+		Branch:  plumbing.NewBranchReferenceName("main"),
+		Hash:    plumbing.NewHash("1111111111111111111111111111111111111111"),
+		Parent:  plumbing.NewHash("2222222222222222222222222222222222222222"),
+		Files:   []string{"CHANGELOG.md", "composer.json"},
+	}
+
+	type fields struct {
+		repo    *__repoMock
+		cfg     *__cfgMock
+		restore bool
+	}
+
+	repoMock := func(clean bool, releaseErr, undoErr error) *__repoMock {
+		repo := &__repoMock{}
+		repo.On("IsClean").Return(clean, nil)
+		repo.On("LastRelease", mock.Anything).Return(rel, releaseErr)
+		repo.On("Undo", rel, mock.Anything).Return(undoErr)
+		return repo
+	}
+
+	cfgMock := func(projects ...config.Project) *__cfgMock {
+		c := &__cfgMock{}
+		c.On("Projects").Return(projects)
+		return c
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		dryRun      bool
+		dirty       bool
+		wantUndo    bool
+		wantRestore bool
+		assertion   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "validate error",
+			fields:    fields{},
+			assertion: assert.Error,
+		},
+		{
+			name: "no release",
+			fields: fields{
+				repo: repoMock(true, git.ErrNoRelease, nil),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "undo",
+			fields: fields{
+				repo: repoMock(false, nil, nil),
+			},
+			wantUndo:  true,
+			assertion: assert.NoError,
+		},
+		{
+			name: "undo error",
+			fields: fields{
+				repo: repoMock(true, nil, assert.AnError),
+			},
+			wantUndo:  true,
+			assertion: assert.Error,
+		},
+		{
+			name: "restore",
+			fields: fields{
+				repo:    repoMock(true, nil, nil),
+				restore: true,
+			},
+			wantUndo:    true,
+			wantRestore: true,
+			assertion:   assert.NoError,
+		},
+		{
+			name: "restore not clean",
+			fields: fields{
+				repo:    repoMock(false, nil, nil),
+				restore: true,
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "restore not clean allowed",
+			fields: fields{
+				repo:    repoMock(false, nil, nil),
+				restore: true,
+			},
+			dirty:       true,
+			wantUndo:    true,
+			wantRestore: true,
+			assertion:   assert.NoError,
+		},
+		{
+			name: "dry run",
+			fields: fields{
+				repo:    repoMock(true, nil, nil),
+				restore: true,
+			},
+			dryRun:    true,
+			assertion: assert.NoError,
+		},
+		{
+			name: "projects",
+			fields: fields{
+				repo: repoMock(true, nil, nil),
+				cfg:  cfgMock(config.Project{Name: "backend", TagPrefix: "backend/v"}),
+			},
+			wantUndo:  true,
+			assertion: assert.NoError,
+		},
+		{
+			name: "project tag format error",
+			fields: fields{
+				repo: repoMock(true, nil, nil),
+				cfg:  cfgMock(config.Project{Name: "backend", TagTemplate: "backend"}),
+			},
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.DryRun, tt.dryRun)
+			viper.Set(key.AllowCommitDirty, tt.dirty)
+			defer viper.Reset()
+
+			a := New(func(args *Args) {
+				if tt.fields.repo != nil {
+					args.Repo = tt.fields.repo
+				}
+
+				if tt.fields.cfg != nil {
+					args.Cfg = tt.fields.cfg
+				}
+
+				args.Restore = tt.fields.restore
+			})
+
+			tt.assertion(t, a.Run(), tt.name)
+
+			if tt.fields.repo == nil {
+				return
+			}
+
+			if tt.wantUndo {
+				tt.fields.repo.AssertCalled(t, "Undo", rel, tt.wantRestore)
+			} else {
+				tt.fields.repo.AssertNotCalled(t, "Undo", mock.Anything, mock.Anything)
+			}
+
+			if tt.fields.cfg != nil && tt.wantUndo {
+				f, _ := git.NewTagFormat("backend/v", "")
+				tt.fields.repo.AssertCalled(t, "LastRelease", []git.TagFormat{f})
+			}
+		})
+	}
+}
+
+type __repoMock struct {
+	mock.Mock
+}
+
+func (m *__repoMock) IsClean() (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *__repoMock) LastRelease(projects ...git.TagFormat) (git.Release, error) {
+	args := m.Called(projects)
+	return args.Get(0).(git.Release), args.Error(1)
+}
+
+func (m *__repoMock) Undo(rel git.Release, restore bool) error {
+	args := m.Called(rel, restore)
+	return args.Error(0)
+}
+
+type __cfgMock struct {
+	mock.Mock
+}
+
+func (m *__cfgMock) Projects() []config.Project {
+	args := m.Called()
+	return args.Get(0).([]config.Project)
+}
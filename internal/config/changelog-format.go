@@ -0,0 +1,80 @@
+package config
+
+// ChangelogFormat is a changelog file format.
+type ChangelogFormat string
+
+// ChangelogFormat values.
+const (
+	FormatMarkdown ChangelogFormat = "markdown" // FormatMarkdown is a markdown changelog (CHANGELOG.md).
+	FormatJSON     ChangelogFormat = "json"     // FormatJSON is a json changelog (CHANGELOG.json).
+	FormatYAML     ChangelogFormat = "yaml"     // FormatYAML is a yaml changelog (CHANGELOG.yaml).
+)
+
+// ChangelogStyle is a markdown changelog style.
+type ChangelogStyle string
+
+// ChangelogStyle values.
+const (
+	StyleConventional   ChangelogStyle = "conventional"   // StyleConventional is a conventional commits changelog.
+	StyleKeepAChangelog ChangelogStyle = "keepachangelog" // StyleKeepAChangelog is a Keep a Changelog changelog.
+)
+
+// String returns string representation of ChangelogStyle.
+func (s ChangelogStyle) String() string {
+	return string(s)
+}
+
+// valid returns true if the style is empty (default) or one of the ChangelogStyle values.
+func (s ChangelogStyle) valid() bool {
+	switch s {
+	case "", StyleConventional, StyleKeepAChangelog:
+		return true
+	default:
+		return false
+	}
+}
+
+// _defaultChangelogFormats is a default changelog formats.
+var _defaultChangelogFormats = []ChangelogFormat{FormatMarkdown}
+
+// String returns string representation of ChangelogFormat.
+func (f ChangelogFormat) String() string {
+	return string(f)
+}
+
+// valid returns true if the format is one of the ChangelogFormat values.
+func (f ChangelogFormat) valid() bool {
+	switch f {
+	case FormatMarkdown, FormatJSON, FormatYAML:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangelogFormats converts strings to changelog formats.
+// If the list is empty, then returns default formats (markdown).
+func ChangelogFormats(s []string) []ChangelogFormat {
+	if len(s) == 0 {
+		return _defaultChangelogFormats
+	}
+
+	res := make([]ChangelogFormat, len(s))
+
+	for i, f := range s {
+		res[i] = ChangelogFormat(f)
+	}
+
+	return res
+}
+
+// formatStrings converts changelog formats to strings.
+func formatStrings(ff []ChangelogFormat) []string {
+	res := make([]string, len(ff))
+
+	for i, f := range ff {
+		res[i] = f.String()
+	}
+
+	return res
+}
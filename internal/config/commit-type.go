@@ -3,7 +3,7 @@ package config
 
 // Commit types.
 const (
-	_CommitFeat     = "feat"     // Features
+	CommitFeat      = "feat"     // Features
 	_CommitFix      = "fix"      // Bug Fixes
 	_CommitPerf     = "perf"     // Performance Improvements
 	_CommitRefactor = "refactor" // Code Refactoring
@@ -16,22 +16,128 @@ const (
 	_CommitCI       = "ci"       // Continuous Integration
 )
 
+// BumpLevel is a version level, required by commit type.
+type BumpLevel string
+
+// BumpLevel values.
+const (
+	BumpMajor BumpLevel = "major" // BumpMajor is a next major version.
+	BumpMinor BumpLevel = "minor" // BumpMinor is a next minor version.
+	BumpPatch BumpLevel = "patch" // BumpPatch is a next patch version.
+	BumpNone  BumpLevel = "none"  // BumpNone is no release.
+)
+
+// String returns string representation of BumpLevel.
+func (b BumpLevel) String() string {
+	return string(b)
+}
+
+// valid returns true if the level is empty (default) or one of the BumpLevel values.
+func (b BumpLevel) valid() bool {
+	switch b {
+	case "", BumpMajor, BumpMinor, BumpPatch, BumpNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// KeepCategory is a Keep a Changelog category (https://keepachangelog.com).
+type KeepCategory string
+
+// KeepCategory values in changelog order.
+const (
+	CategoryAdded      KeepCategory = "Added"      // CategoryAdded is for new features.
+	CategoryChanged    KeepCategory = "Changed"    // CategoryChanged is for changes in existing functionality.
+	CategoryDeprecated KeepCategory = "Deprecated" // CategoryDeprecated is for soon-to-be removed features.
+	CategoryRemoved    KeepCategory = "Removed"    // CategoryRemoved is for now removed features.
+	CategoryFixed      KeepCategory = "Fixed"      // CategoryFixed is for any bug fixes.
+	CategorySecurity   KeepCategory = "Security"   // CategorySecurity is in case of vulnerabilities.
+)
+
+// KeepCategories is a list of Keep a Changelog categories in changelog order.
+var KeepCategories = []KeepCategory{
+	CategoryAdded,
+	CategoryChanged,
+	CategoryDeprecated,
+	CategoryRemoved,
+	CategoryFixed,
+	CategorySecurity,
+}
+
+// String returns string representation of KeepCategory.
+func (k KeepCategory) String() string {
+	return string(k)
+}
+
+// valid returns true if the category is empty (default) or one of the KeepCategory values.
+func (k KeepCategory) valid() bool {
+	if k == "" {
+		return true
+	}
+
+	for _, c := range KeepCategories {
+		if k == c {
+			return true
+		}
+	}
+
+	return false
+}
+
 // CommitName is a commit type name.
 type CommitName struct {
 	Type string `yaml:"type"`
 	Name string `yaml:"name"`
+	// Bump is a version level, required by commit type. Optional.
+	Bump BumpLevel `yaml:"bump"`
+	// Category is a Keep a Changelog category for keepachangelog style. Optional.
+	Category KeepCategory `yaml:"category"`
+}
+
+// KeepCategory returns a Keep a Changelog category of commit type.
+// If Category is empty, then returns Added for feat, Fixed for fix, Removed for revert and Changed for others.
+func (c CommitName) KeepCategory() KeepCategory {
+	if c.Category != "" {
+		return c.Category
+	}
+
+	switch c.Type {
+	case CommitFeat:
+		return CategoryAdded
+	case _CommitFix:
+		return CategoryFixed
+	case _CommitRevert:
+		return CategoryRemoved
+	default:
+		return CategoryChanged
+	}
+}
+
+// Level returns a version level, required by commit type.
+// If Bump is empty, then returns minor for feat and patch for others.
+func (c CommitName) Level() BumpLevel {
+	if c.Bump != "" {
+		return c.Bump
+	}
+
+	if c.Type == CommitFeat {
+		return BumpMinor
+	}
+
+	return BumpPatch
 }
 
 var _defaultCommitNames = []CommitName{
-	{Type: _CommitFeat, Name: "Features"},
-	{Type: _CommitFix, Name: "Bug Fixes"},
-	{Type: _CommitPerf, Name: "Performance Improvements"},
-	{Type: _CommitRefactor, Name: "Code Refactoring"},
-	{Type: _CommitStyle, Name: "Styles"},
-	{Type: _CommitTest, Name: "Tests"},
-	{Type: _CommitBuild, Name: "Builds"},
-	{Type: _CommitDocs, Name: "Documentation"},
-	{Type: _CommitRevert, Name: "Reverts"},
-	{Type: _CommitCI, Name: "Continuous Integration"},
-	{Type: CommitChore, Name: "Other changes"},
+	{Type: CommitFeat, Name: "Features", Bump: BumpMinor},
+	{Type: _CommitFix, Name: "Bug Fixes", Bump: BumpPatch},
+	{Type: _CommitPerf, Name: "Performance Improvements", Bump: BumpPatch},
+	{Type: _CommitRefactor, Name: "Code Refactoring", Bump: BumpPatch},
+	{Type: _CommitStyle, Name: "Styles", Bump: BumpPatch},
+	{Type: _CommitTest, Name: "Tests", Bump: BumpPatch},
+	{Type: _CommitBuild, Name: "Builds", Bump: BumpPatch},
+	{Type: _CommitDocs, Name: "Documentation", Bump: BumpPatch},
+	{Type: _CommitRevert, Name: "Reverts", Bump: BumpPatch},
+	{Type: _CommitCI, Name: "Continuous Integration", Bump: BumpPatch},
+	{Type: CommitChore, Name: "Other changes", Bump: BumpPatch},
 }
@@ -3,17 +3,21 @@ package config
 import (
 	"errors"
 	"fmt"
+	"go/token"
 	"html/template"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/klimby/version/internal/config/key"
 	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/service/git"
 	"github.com/klimby/version/pkg/version"
+	"github.com/klimby/version/pkg/yamlpath"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v2"
 )
@@ -43,28 +47,45 @@ type C struct {
 	ChangelogOptions changelogOptions `yaml:"changelog"`
 	// Bump is a list of files for bump.
 	Bump []BumpFile `yaml:"bump"`
+	// BuildMetadata is a build metadata template for version in bump files.
+	// Example: "{{.ShortHash}}" -> 1.2.3+abc1234.
+	BuildMetadata string `yaml:"buildMetadata"`
+	// SubProjects is a list of sub-projects with independent versions (monorepo).
+	SubProjects []Project `yaml:"projects"`
 
 	rw configRW
+	// project is a selected project (--project flag). Nil for root project.
+	project *Project
 }
 
 type configRW interface {
 	Read(patch string) (io.ReadCloser, error)
 	Exists(p string) bool
 	Write(patch string, flag int) (io.WriteCloser, error)
+	Glob(pattern string) ([]string, error)
 }
 
 // newConfig returns a new configuration.
 func newConfig(rw configRW) (_ C, err error) {
 	c := C{
-		Version: version.V(viper.GetString(key.Version)),
-		Backup:  viper.GetBool(key.Backup),
-		Before:  []Command{},
-		After:   []Command{},
+		Version:       version.V(viper.GetString(key.Version)),
+		Backup:        viper.GetBool(key.Backup),
+		Before:        []Command{},
+		BuildMetadata: viper.GetString(key.BuildMetadata),
+		After:         []Command{},
 		GitOptions: gitOptions{
 			AllowCommitDirty:      viper.GetBool(key.AllowCommitDirty),
 			AutoGenerateNextPatch: viper.GetBool(key.AutoGenerateNextPatch),
 			AllowDowngrades:       viper.GetBool(key.AllowDowngrades),
 			RemoteURL:             viper.GetString(key.RemoteURL),
+			TagPrefix:             viper.GetString(key.GitTagPrefix),
+			TagTemplate:           viper.GetString(key.GitTagTemplate),
+			Push:                  viper.GetBool(key.GitPush),
+			PushRemote:            viper.GetString(key.GitPushRemote),
+			Sign:                  git.SignFormat(viper.GetString(key.GitSign)),
+			SignKey:               viper.GetString(key.GitSignKey),
+			CommitMessage:         viper.GetString(key.GitCommitMessage),
+			TagMessage:            viper.GetString(key.GitTagMessage),
 		},
 		ChangelogOptions: changelogOptions{
 			Generate:    viper.GetBool(key.GenerateChangelog),
@@ -74,6 +95,12 @@ func newConfig(rw configRW) (_ C, err error) {
 			ShowAuthor:  viper.GetBool(key.ChangelogShowAuthor),
 			ShowBody:    viper.GetBool(key.ChangelogShowBody),
 			CommitTypes: _defaultCommitNames,
+
+			FoldPrerelease: viper.GetBool(key.ChangelogFoldPrerelease),
+			Template:       fsys.File(viper.GetString(key.ChangelogTemplate)),
+			HeaderTemplate: fsys.File(viper.GetString(key.ChangelogHeaderTemplate)),
+			Formats:        ChangelogFormats(viper.GetStringSlice(key.ChangelogFormats)),
+			Style:          ChangelogStyle(viper.GetString(key.ChangelogStyle)),
 		},
 		rw: rw,
 	}
@@ -101,16 +128,44 @@ func newConfig(rw configRW) (_ C, err error) {
 		return c, fmt.Errorf("decode config file error: %w", err)
 	}
 
+	for i := range c.SubProjects {
+		c.SubProjects[i] = c.SubProjects[i].withDefaults()
+	}
+
 	c.IsFileConfig = true
 
 	return c, nil
 }
 
 // BumpFiles returns a list of files for bump.
+// If the project is selected, then returns project files.
 func (c C) BumpFiles() []BumpFile {
+	if c.project != nil {
+		return c.project.Bump
+	}
+
 	return c.Bump
 }
 
+// selectProject selects the project by name and applies its options.
+func (c *C) selectProject(name string) error {
+	for i := range c.SubProjects {
+		if p := &c.SubProjects[i]; p.Name == name {
+			c.project = p
+			p.apply()
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf(`%w: project %s not found`, errConfig, name)
+}
+
+// Projects returns a list of sub-projects.
+func (c C) Projects() []Project {
+	return c.SubProjects
+}
+
 // CommandsBefore returns a list of commands that are executed before the main command.
 func (c C) CommandsBefore() []Command {
 	return c.Before
@@ -145,8 +200,11 @@ func (c C) Generate() (err error) {
 
 	//nolint:revive
 	c.Version = version.V(viper.GetString(key.Version))
+	// Template files can be changed by templates dump.
+	c.ChangelogOptions.Template = fsys.File(viper.GetString(key.ChangelogTemplate))
+	c.ChangelogOptions.HeaderTemplate = fsys.File(viper.GetString(key.ChangelogHeaderTemplate))
 
-	tmpl, err := template.New("config").Parse(_configYamlTemplate)
+	tmpl, err := template.New("config").Funcs(template.FuncMap{"quote": yamlQuote, "indent": yamlIndent, "bumps": newBumpsTpl}).Parse(_configYamlTemplate)
 	if err != nil {
 		return fmt.Errorf("parse config template error: %w", err)
 	}
@@ -158,8 +216,41 @@ func (c C) Generate() (err error) {
 	return nil
 }
 
+// yamlQuote returns s as single-quoted YAML string without HTML escaping (for regexps).
+func yamlQuote(s string) template.HTML {
+	//nolint:gosec
+	return template.HTML("'" + strings.ReplaceAll(s, "'", "''") + "'")
+}
+
+// bumpsTpl is a bump files list for the config template, indented by Indent.
+type bumpsTpl struct {
+	Indent string
+	Bump   []BumpFile
+}
+
+// newBumpsTpl returns bump files for the "bump" config template, indented by n spaces.
+// The same template is used for root and project bump sections.
+func newBumpsTpl(n int, bump []BumpFile) bumpsTpl {
+	return bumpsTpl{Indent: strings.Repeat(" ", n), Bump: bump}
+}
+
+// yamlIndent returns s as YAML block scalar lines, indented by n spaces, without HTML escaping.
+func yamlIndent(s string, n int) template.HTML {
+	lines := strings.Split(s, "\n")
+
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = strings.Repeat(" ", n) + l
+		}
+	}
+
+	//nolint:gosec
+	return template.HTML(strings.Join(lines, "\n"))
+}
+
 // Validate validates the configuration.
-func (c C) Validate() error {
+// Glob patterns of the bump files are expanded to the matched files.
+func (c *C) Validate() error {
 	if !c.IsFileConfig {
 		return nil
 	}
@@ -176,14 +267,41 @@ func (c C) Validate() error {
 		}
 	}
 
-	if err := c.ChangelogOptions.validate(); err != nil {
+	if err := c.GitOptions.validate(); err != nil {
 		return err
 	}
 
-	for _, f := range c.Bump {
-		if err := f.validate(c.rw); err != nil {
+	if err := c.ChangelogOptions.validate(c.rw); err != nil {
+		return err
+	}
+
+	bump, err := expandBumpFiles(c.rw, c.Bump)
+	if err != nil {
+		return err
+	}
+
+	c.Bump = bump
+
+	names := make(map[string]bool, len(c.SubProjects))
+
+	for i := range c.SubProjects {
+		p := &c.SubProjects[i]
+
+		if err := p.validate(c.rw); err != nil {
 			return err
 		}
+
+		if names[p.Name] {
+			return fmt.Errorf(`%w: project %s is duplicated`, errConfig, p.Name)
+		}
+
+		names[p.Name] = true
+	}
+
+	if c.BuildMetadata != "" {
+		if _, err := template.New("build").Parse(c.BuildMetadata); err != nil {
+			return fmt.Errorf(`%w: build metadata template %s error: %w`, errConfig, c.BuildMetadata, err)
+		}
 	}
 
 	return validateVersion(c.Version, _VersionWarningUpdate, _VersionCriticalUpdate)
@@ -259,6 +377,54 @@ type gitOptions struct {
 	AllowDowngrades bool `yaml:"allowDowngrades"`
 	// RemoteURL is a remote repository URL.
 	RemoteURL string `yaml:"remoteUrl"`
+	// TagPrefix is a git tag prefix (v for v1.2.3).
+	TagPrefix string `yaml:"tagPrefix"`
+	// TagTemplate is a git tag name template with {{.Version}} variable (overrides TagPrefix).
+	// Example: release-{{.Version}}.
+	TagTemplate string `yaml:"tagTemplate"`
+	// Push is a flag that indicates that the release commit and tag are pushed to the remote.
+	Push bool `yaml:"push"`
+	// PushRemote is a remote name for push. Default: origin.
+	PushRemote string `yaml:"pushRemote"`
+	// Sign is a release commit and tag signature format: gpg or ssh. Empty - not signed.
+	Sign git.SignFormat `yaml:"sign"`
+	// SignKey is a GPG keyring (exported secret key, required for gpg) or SSH key path.
+	// Default for ssh: user.signingkey from git config.
+	SignKey string `yaml:"signKey"`
+	// CommitMessage is a release commit message template.
+	// Variables: {{.Version}}, {{.Previous}}, {{.Tag}}, {{.Date}}, {{.Notes}}.
+	CommitMessage string `yaml:"commitMessage"`
+	// TagMessage is a release tag message template with the same variables.
+	TagMessage string `yaml:"tagMessage"`
+}
+
+// validate validates the git options.
+func (g gitOptions) validate() error {
+	if _, err := git.NewTagFormat(g.TagPrefix, g.TagTemplate); err != nil {
+		return fmt.Errorf(`%w: %w`, errConfig, err)
+	}
+
+	if !g.Sign.Valid() {
+		return fmt.Errorf(`%w: git sign format %s is invalid (allowed: gpg, ssh)`, errConfig, g.Sign)
+	}
+
+	if g.Sign == git.SignGPG && g.SignKey == "" {
+		return fmt.Errorf(`%w: %w`, errConfig, git.ErrGPGKeyRequired)
+	}
+
+	if g.CommitMessage != "" {
+		if err := git.ValidateMessage(g.CommitMessage); err != nil {
+			return fmt.Errorf(`%w: git commit message: %w`, errConfig, err)
+		}
+	}
+
+	if g.TagMessage != "" {
+		if err := git.ValidateMessage(g.TagMessage); err != nil {
+			return fmt.Errorf(`%w: git tag message: %w`, errConfig, err)
+		}
+	}
+
+	return nil
 }
 
 // changelogOptions is a changelog options.
@@ -277,10 +443,31 @@ type changelogOptions struct {
 	ShowBody bool `yaml:"showBody"`
 	// CommitTypes is a commit types for changelog.
 	CommitTypes []CommitName `yaml:"commitTypes"`
+	// FoldPrerelease is a flag that indicates that pre-release sections are folded into the release section.
+	FoldPrerelease bool `yaml:"foldPrerelease"`
+	// Template is a version section template file (Go text/template). Optional.
+	Template fsys.File `yaml:"template"`
+	// HeaderTemplate is a changelog header template file (Go text/template). Optional.
+	HeaderTemplate fsys.File `yaml:"headerTemplate"`
+	// Formats is a list of changelog file formats: markdown, json, yaml. Default: markdown.
+	// Json and yaml files have changelog file name with .json and .yaml extensions.
+	Formats []ChangelogFormat `yaml:"formats"`
+	// Style is a markdown changelog style: conventional or keepachangelog. Default: conventional.
+	Style ChangelogStyle `yaml:"style"`
 }
 
 // validate validates the changelog options.
-func (c changelogOptions) validate() error {
+func (c changelogOptions) validate(rw bumpRW) error {
+	for _, t := range c.CommitTypes {
+		if !t.Bump.valid() {
+			return fmt.Errorf(`%w: commit type %s bump level %s is invalid (allowed: major, minor, patch, none)`, errConfig, t.Type, t.Bump)
+		}
+
+		if !t.Category.valid() {
+			return fmt.Errorf(`%w: commit type %s category %s is invalid (allowed: Added, Changed, Deprecated, Removed, Fixed, Security)`, errConfig, t.Type, t.Category)
+		}
+	}
+
 	if !c.Generate {
 		return nil
 	}
@@ -295,13 +482,42 @@ func (c changelogOptions) validate() error {
 		}
 	}
 
+	if !c.Style.valid() {
+		return fmt.Errorf(`%w: changelog style %s is invalid (allowed: conventional, keepachangelog)`, errConfig, c.Style)
+	}
+
+	formats := make(map[ChangelogFormat]bool, len(c.Formats))
+
+	for _, f := range c.Formats {
+		if !f.valid() {
+			return fmt.Errorf(`%w: changelog format %s is invalid (allowed: markdown, json, yaml)`, errConfig, f)
+		}
+
+		if formats[f] {
+			return fmt.Errorf(`%w: changelog format %s is duplicated`, errConfig, f)
+		}
+
+		formats[f] = true
+	}
+
+	for _, f := range []fsys.File{c.Template, c.HeaderTemplate} {
+		if !f.Empty() && !rw.Exists(f.Path()) {
+			return fmt.Errorf(`%w: changelog template file %s does not exist`, errConfig, f)
+		}
+	}
+
 	return nil
 }
 
 // BumpFile is a file for bump.
 type BumpFile struct {
-	// File path.
+	// File path or glob pattern, for example packages/*/package.json or **/Chart.yaml.
+	// Pattern is expanded to the matched files on validation.
 	File fsys.File `yaml:"file"`
+	// Exclude is a list of glob patterns for files, that are excluded from File pattern matches.
+	Exclude []string `yaml:"exclude"`
+	// Optional is a flag that the file may not exist or the File pattern may match no files.
+	Optional bool `yaml:"optional"`
 	// RegExp for string for search version.
 	// If not will be found version regexp in strings from start to end.
 	RegExp []string `yaml:"regexp"`
@@ -309,6 +525,61 @@ type BumpFile struct {
 	Start int `yaml:"start"`
 	// End string for search version. If 0 will be searched to end of file.
 	End int `yaml:"end"`
+	// Key is a dotted path to the version field in TOML or YAML file, for example package.version,
+	// or a package-qualified identifier of the version constant or variable in Go file, for example internal/build.Version.
+	// Default: package.version for Cargo.toml, project.version or tool.poetry.version for pyproject.toml,
+	// version and appVersion for Chart.yaml, info.version for openapi.yaml.
+	Key string `yaml:"key"`
+	// Keys are dotted paths to the version fields in TOML or YAML file (in addition to Key).
+	Keys []string `yaml:"keys"`
+	// Type is a file type. Optional, by default it is detected by file name.
+	Type BumpType `yaml:"type"`
+	// Multiline is a flag for multi-line mode: regexps with version group are applied to the text
+	// from start to end lines (not to every line).
+	Multiline bool `yaml:"multiline"`
+	// Required is a flag that the file must be changed, else version is not created. Default: true.
+	Required *bool `yaml:"required"`
+	// AnyVersion is a flag for replace any version in custom and JSON files.
+	// By default only the current version is replaced.
+	AnyVersion bool `yaml:"anyVersion"`
+	// XPath is a path to the version element in XML file, for example /project/version.
+	// Default: /project/version for pom.xml, /Project/PropertyGroup/Version for *.csproj.
+	XPath string `yaml:"xpath"`
+}
+
+// VersionGroup is a regexp named group for version replacement: (?P<version>...).
+const VersionGroup = "version"
+
+// BumpType is a bump file type.
+type BumpType string
+
+const (
+	// BumpTypeTOML is a TOML file type.
+	BumpTypeTOML BumpType = "toml"
+	// BumpTypeYAML is a YAML file type.
+	BumpTypeYAML BumpType = "yaml"
+	// BumpTypeXML is a XML file type.
+	BumpTypeXML BumpType = "xml"
+	// BumpTypeGo is a Go source file type.
+	BumpTypeGo BumpType = "go"
+)
+
+// _XPathRegexp is a regexp for XML path: absolute path of element local names.
+var _XPathRegexp = regexp.MustCompile(`^(/[A-Za-z_][\w.\-]*)+$`)
+
+// valid returns true if the type is empty or known.
+func (t BumpType) valid() bool {
+	switch t {
+	case "", BumpTypeTOML, BumpTypeYAML, BumpTypeXML, BumpTypeGo:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRequired returns true if the file must be changed (default).
+func (f BumpFile) IsRequired() bool {
+	return f.Required == nil || *f.Required
 }
 
 // HasPositions returns true if the file has start and end positions.
@@ -323,8 +594,222 @@ func (f BumpFile) IsPredefinedJSON() bool {
 	return n == "composer.json" || n == "package.json"
 }
 
+// IsLockfile returns true if the file is npm or composer lock file
+// (package-lock.json, npm-shrinkwrap.json or composer.lock).
+func (f BumpFile) IsLockfile() bool {
+	if f.Type != "" {
+		return false
+	}
+
+	switch filepath.Base(f.File.String()) {
+	case "package-lock.json", "npm-shrinkwrap.json", "composer.lock":
+		return true
+	default:
+		return false
+	}
+}
+
+// Lockfiles returns lock files of predefined JSON file, that are bumped with it:
+// package-lock.json and npm-shrinkwrap.json for package.json, composer.lock for composer.json.
+func (f BumpFile) Lockfiles() []fsys.File {
+	if f.Type != "" {
+		return nil
+	}
+
+	var names []string
+
+	switch filepath.Base(f.File.String()) {
+	case "package.json":
+		names = []string{"package-lock.json", "npm-shrinkwrap.json"}
+	case "composer.json":
+		names = []string{"composer.lock"}
+	default:
+		return nil
+	}
+
+	dir := filepath.Dir(f.File.String())
+	files := make([]fsys.File, 0, len(names))
+
+	for _, n := range names {
+		files = append(files, fsys.File(filepath.Join(dir, n)))
+	}
+
+	return files
+}
+
+// IsTOML returns true if the file is TOML file (by type or by .toml extension with key paths).
+// TOML files without key paths are processed as custom files.
+func (f BumpFile) IsTOML() bool {
+	if f.Type != "" {
+		return f.Type == BumpTypeTOML
+	}
+
+	return strings.EqualFold(filepath.Ext(f.File.String()), ".toml") && len(f.Paths()) > 0
+}
+
+// IsYAML returns true if the file is YAML file (by type or by .yaml or .yml extension with key paths).
+// YAML files without key paths are processed as custom files.
+func (f BumpFile) IsYAML() bool {
+	if f.Type != "" {
+		return f.Type == BumpTypeYAML
+	}
+
+	ext := strings.ToLower(filepath.Ext(f.File.String()))
+
+	return (ext == ".yaml" || ext == ".yml") && len(f.Paths()) > 0
+}
+
+// Paths returns dotted key paths for TOML or YAML file.
+// If Key and Keys are empty, returns predefined paths for Cargo.toml, pyproject.toml, Chart.yaml and OpenAPI files.
+func (f BumpFile) Paths() []string {
+	if f.Key != "" || len(f.Keys) > 0 {
+		paths := make([]string, 0, len(f.Keys)+1)
+
+		if f.Key != "" {
+			paths = append(paths, f.Key)
+		}
+
+		return append(paths, f.Keys...)
+	}
+
+	switch filepath.Base(f.File.String()) {
+	case "Cargo.toml":
+		return []string{"package.version", "workspace.package.version"}
+	case "pyproject.toml":
+		return []string{"project.version", "tool.poetry.version"}
+	case "Chart.yaml":
+		return []string{"version", "appVersion"}
+	case "openapi.yaml", "openapi.yml", "swagger.yaml", "swagger.yml":
+		return []string{"info.version"}
+	default:
+		return nil
+	}
+}
+
+// IsGo returns true if the file is Go source file (by type or by .go extension with key).
+func (f BumpFile) IsGo() bool {
+	if f.Type != "" {
+		return f.Type == BumpTypeGo
+	}
+
+	return filepath.Ext(f.File.String()) == ".go" && f.Key != ""
+}
+
+// GoIdent returns package path and name of the identifier from key (internal/build.Version).
+// Package path is empty, if the key is not qualified.
+func (f BumpFile) GoIdent() (pkg, name string) {
+	i := strings.LastIndex(f.Key, ".")
+	if i < 0 {
+		return "", f.Key
+	}
+
+	return f.Key[:i], f.Key[i+1:]
+}
+
+// IsXML returns true if the file is XML file (by type, xpath, pom.xml or *.csproj).
+func (f BumpFile) IsXML() bool {
+	if f.Type != "" {
+		return f.Type == BumpTypeXML
+	}
+
+	return len(f.XPaths()) > 0
+}
+
+// XPaths returns paths to the version elements in XML file.
+// If XPath is empty, returns predefined paths for pom.xml and *.csproj.
+func (f BumpFile) XPaths() []string {
+	if f.XPath != "" {
+		return []string{f.XPath}
+	}
+
+	n := filepath.Base(f.File.String())
+
+	switch {
+	case n == "pom.xml":
+		return []string{"/project/version"}
+	case strings.EqualFold(filepath.Ext(n), ".csproj"):
+		return []string{"/Project/PropertyGroup/Version"}
+	default:
+		return nil
+	}
+}
+
 type bumpRW interface {
 	Exists(p string) bool
+	Read(patch string) (io.ReadCloser, error)
+	Glob(pattern string) ([]string, error)
+}
+
+// expandBumpFiles expands glob patterns of the files and validates every file.
+func expandBumpFiles(rw bumpRW, files []BumpFile) ([]BumpFile, error) {
+	res := make([]BumpFile, 0, len(files))
+
+	for _, f := range files {
+		expanded, err := f.expand(rw)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range expanded {
+			if err := e.validate(rw); err != nil {
+				return nil, err
+			}
+		}
+
+		res = append(res, expanded...)
+	}
+
+	return res, nil
+}
+
+// expand returns files, that match the glob pattern of the file, without excluded files.
+// If the file is not a pattern, then returns the file itself.
+// Optional file, that does not exist, or optional pattern without matches returns empty list.
+func (f BumpFile) expand(rw bumpRW) ([]BumpFile, error) {
+	for _, e := range f.Exclude {
+		if _, err := fsys.Match(e, ""); err != nil {
+			return nil, fmt.Errorf(`%w: file %s exclude pattern %s error: %w`, errConfig, f.File, e, err)
+		}
+	}
+
+	if !fsys.IsGlob(f.File.String()) {
+		if f.Optional && !rw.Exists(f.File.Path()) {
+			return nil, nil
+		}
+
+		return []BumpFile{f}, nil
+	}
+
+	matches, err := rw.Glob(f.File.Path())
+	if err != nil {
+		return nil, fmt.Errorf(`%w: file pattern %s error: %w`, errConfig, f.File, err)
+	}
+
+	files := make([]BumpFile, 0, len(matches))
+
+	for _, m := range matches {
+		file := fsys.File(m)
+		if !f.File.IsAbs() {
+			file = fsys.File(filepath.ToSlash(file.Rel()))
+		}
+
+		if slices.ContainsFunc(f.Exclude, func(e string) bool {
+			ok, _ := fsys.Match(e, file.String())
+			return ok
+		}) {
+			continue
+		}
+
+		bmp := f
+		bmp.File = file
+		files = append(files, bmp)
+	}
+
+	if len(files) == 0 && !f.Optional {
+		return nil, fmt.Errorf(`%w: file pattern %s matches no files`, errConfig, f.File)
+	}
+
+	return files, nil
 }
 
 // validate BumpFile validates the file for bump.
@@ -335,20 +820,129 @@ func (f BumpFile) validate(rw bumpRW) error {
 		return fmt.Errorf(`%w: file %s does not exist`, errConfig, f.File)
 	}
 
-	if f.IsPredefinedJSON() {
+	if !f.Type.valid() {
+		return fmt.Errorf(`%w: file %s type %s is invalid`, errConfig, f.File, f.Type)
+	}
+
+	if (f.IsPredefinedJSON() || f.IsLockfile()) && f.Type == "" {
+		return nil
+	}
+
+	if f.IsGo() {
+		if _, name := f.GoIdent(); !token.IsIdentifier(name) {
+			return fmt.Errorf(`%w: file %s key %q is not a Go identifier`, errConfig, f.File, f.Key)
+		}
+
 		return nil
 	}
 
+	if f.IsXML() {
+		return f.validateXML()
+	}
+
+	if f.IsTOML() || f.IsYAML() {
+		paths := f.Paths()
+		if len(paths) == 0 {
+			return fmt.Errorf(`%w: file %s key is required`, errConfig, f.File)
+		}
+
+		for _, k := range paths {
+			for _, p := range strings.Split(k, ".") {
+				if strings.TrimSpace(p) == "" {
+					return fmt.Errorf(`%w: file %s key %s is invalid`, errConfig, f.File, k)
+				}
+			}
+		}
+
+		if f.IsYAML() {
+			return f.validateYAML(rw)
+		}
+
+		return nil
+	}
+
+	if f.Key != "" || len(f.Keys) > 0 {
+		return fmt.Errorf(`%w: file %s key is supported only for TOML and YAML files`, errConfig, f.File)
+	}
+
 	if f.Start > f.End {
 		return fmt.Errorf(`%w: file %s start position is greater than end position`, errConfig, f.File)
 	}
 
-	if len(f.RegExp) > 0 {
-		for _, r := range f.RegExp {
-			if _, err := regexp.Compile(r); err != nil {
-				return fmt.Errorf(`%w: file %s regexp %s error: %w`, errConfig, f.File, r, err)
+	if f.Multiline && len(f.RegExp) == 0 {
+		return fmt.Errorf(`%w: file %s multiline mode requires regexp`, errConfig, f.File)
+	}
+
+	for _, r := range f.RegExp {
+		rgx, err := regexp.Compile(r)
+		if err != nil {
+			return fmt.Errorf(`%w: file %s regexp %s error: %w`, errConfig, f.File, r, err)
+		}
+
+		if rgx.SubexpIndex(VersionGroup) >= 0 {
+			continue
+		}
+
+		// regexp without named groups only selects lines, it is not allowed in multi-line mode.
+		if f.Multiline || slices.ContainsFunc(rgx.SubexpNames(), func(n string) bool { return n != "" }) {
+			return fmt.Errorf(`%w: file %s regexp %s has no named group (?P<%s>...)`, errConfig, f.File, r, VersionGroup)
+		}
+	}
+
+	return nil
+}
+
+// validateYAML checks that YAML file contains scalar values for all configured key paths.
+// Predefined paths are optional (appVersion in Chart.yaml), at least one of them must exist.
+func (f BumpFile) validateYAML(rw bumpRW) (err error) {
+	r, err := rw.Read(f.File.Path())
+	if err != nil {
+		return fmt.Errorf(`%w: file %s read error: %w`, errConfig, f.File, err)
+	}
+
+	defer func() {
+		if e := r.Close(); e != nil && err == nil {
+			err = fmt.Errorf(`%w: file %s close error: %w`, errConfig, f.File, e)
+		}
+	}()
+
+	docs, err := yamlpath.Decode(r)
+	if err != nil {
+		return fmt.Errorf(`%w: file %s parse error: %w`, errConfig, f.File, err)
+	}
+
+	paths := f.Paths()
+
+	if f.Key == "" && len(f.Keys) == 0 {
+		for _, p := range paths {
+			if len(yamlpath.Scalars(docs, p)) > 0 {
+				return nil
 			}
 		}
+
+		return fmt.Errorf(`%w: file %s keys %s not found or are not scalars`, errConfig, f.File, strings.Join(paths, ", "))
+	}
+
+	for _, p := range paths {
+		if len(yamlpath.Scalars(docs, p)) == 0 {
+			return fmt.Errorf(`%w: file %s key %s not found or is not a scalar`, errConfig, f.File, p)
+		}
+	}
+
+	return nil
+}
+
+// validateXML checks XML paths. Supported only absolute paths of element names, for example /project/version.
+func (f BumpFile) validateXML() error {
+	paths := f.XPaths()
+	if len(paths) == 0 {
+		return fmt.Errorf(`%w: file %s xpath is required`, errConfig, f.File)
+	}
+
+	for _, p := range paths {
+		if !_XPathRegexp.MatchString(p) {
+			return fmt.Errorf(`%w: file %s xpath %s is invalid`, errConfig, f.File, p)
+		}
 	}
 
 	return nil
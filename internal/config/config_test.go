@@ -2,14 +2,17 @@ package config
 
 import (
 	"fmt"
+	"html/template"
 	"io/fs"
 	"testing"
 
 	"github.com/klimby/version/internal/config/key"
 	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/service/git"
 	"github.com/klimby/version/pkg/version"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 )
 
 func TestC_Getters(t *testing.T) {
@@ -154,6 +157,7 @@ func TestC_Validate(t *testing.T) {
 		GitOptions       gitOptions
 		ChangelogOptions changelogOptions
 		Bump             []BumpFile
+		BuildMetadata    string
 		rw               *__rwMock
 	}
 
@@ -209,6 +213,75 @@ func TestC_Validate(t *testing.T) {
 			},
 			assertion: assert.Error,
 		},
+		{
+			name: "invalid tag template",
+			fields: fields{
+				IsFileConfig: true,
+				GitOptions: gitOptions{
+					TagTemplate: "release",
+				},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "invalid sign format",
+			fields: fields{
+				IsFileConfig: true,
+				GitOptions: gitOptions{
+					Sign: "x509",
+				},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "gpg sign without key",
+			fields: fields{
+				IsFileConfig: true,
+				GitOptions: gitOptions{
+					Sign: git.SignGPG,
+				},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "gpg sign with key",
+			fields: fields{
+				IsFileConfig: true,
+				GitOptions: gitOptions{
+					Sign:    git.SignGPG,
+					SignKey: "key.asc",
+				},
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "invalid commit message",
+			fields: fields{
+				IsFileConfig: true,
+				GitOptions: gitOptions{
+					CommitMessage: "release {{.Unknown}}",
+				},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "invalid tag message",
+			fields: fields{
+				IsFileConfig: true,
+				GitOptions: gitOptions{
+					TagMessage: "release {{.Version",
+				},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "invalid build metadata",
+			fields: fields{
+				IsFileConfig:  true,
+				BuildMetadata: "{{.ShortHash",
+			},
+			assertion: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -221,6 +294,7 @@ func TestC_Validate(t *testing.T) {
 				GitOptions:       tt.fields.GitOptions,
 				ChangelogOptions: tt.fields.ChangelogOptions,
 				Bump:             tt.fields.Bump,
+				BuildMetadata:    tt.fields.BuildMetadata,
 				rw:               tt.fields.rw,
 			}
 
@@ -339,6 +413,10 @@ func Test_changelogOptions_validate(t *testing.T) {
 		ShowAuthor  bool
 		ShowBody    bool
 		CommitTypes []CommitName
+		Template    fsys.File
+		Formats     []ChangelogFormat
+		Style       ChangelogStyle
+		rw          *__rwMock
 	}
 	tests := []struct {
 		name      string
@@ -377,12 +455,94 @@ func Test_changelogOptions_validate(t *testing.T) {
 			},
 			assertion: assert.Error,
 		},
+		{
+			name: "invalid bump level",
+			fields: fields{
+				Generate:    false,
+				CommitTypes: []CommitName{{Type: "type", Name: "name", Bump: "foo"}},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "template file does not exist",
+			fields: fields{
+				Generate: true,
+				FileName: fsys.File("file"),
+				Template: fsys.File("changelog.tpl"),
+				rw:       __newRWMock(__rwMockArgs{exists: false}),
+			},
+			assertion: assert.Error,
+		},
 		{
 			name: "ok",
 			fields: fields{
 				Generate:    true,
 				FileName:    fsys.File("file"),
-				CommitTypes: []CommitName{{Type: "type", Name: "name"}},
+				CommitTypes: []CommitName{{Type: "type", Name: "name", Bump: BumpNone}},
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "invalid category",
+			fields: fields{
+				Generate:    false,
+				CommitTypes: []CommitName{{Type: "type", Name: "name", Category: "Other"}},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "invalid style",
+			fields: fields{
+				Generate: true,
+				FileName: fsys.File("file"),
+				Style:    "foo",
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "ok keepachangelog",
+			fields: fields{
+				Generate:    true,
+				FileName:    fsys.File("file"),
+				Style:       StyleKeepAChangelog,
+				CommitTypes: []CommitName{{Type: "type", Name: "name", Category: CategoryDeprecated}},
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "invalid format",
+			fields: fields{
+				Generate: true,
+				FileName: fsys.File("file"),
+				Formats:  []ChangelogFormat{FormatMarkdown, "xml"},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "duplicated format",
+			fields: fields{
+				Generate: true,
+				FileName: fsys.File("file"),
+				Formats:  []ChangelogFormat{FormatJSON, FormatJSON},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "ok with formats",
+			fields: fields{
+				Generate: true,
+				FileName: fsys.File("file"),
+				Formats:  []ChangelogFormat{FormatMarkdown, FormatJSON, FormatYAML},
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "ok with template",
+			fields: fields{
+				Generate: true,
+				FileName: fsys.File("file"),
+				Template: fsys.File("changelog.tpl"),
+				rw:       __newRWMock(__rwMockArgs{exists: true}),
 			},
 			assertion: assert.NoError,
 		},
@@ -397,9 +557,80 @@ func Test_changelogOptions_validate(t *testing.T) {
 				ShowAuthor:  tt.fields.ShowAuthor,
 				ShowBody:    tt.fields.ShowBody,
 				CommitTypes: tt.fields.CommitTypes,
+				Template:    tt.fields.Template,
+				Formats:     tt.fields.Formats,
+				Style:       tt.fields.Style,
 			}
 
-			tt.assertion(t, c.validate())
+			tt.assertion(t, c.validate(tt.fields.rw))
+		})
+	}
+}
+
+func TestCommitName_Level(t *testing.T) {
+	tests := []struct {
+		name string
+		c    CommitName
+		want BumpLevel
+	}{
+		{
+			name: "configured",
+			c:    CommitName{Type: "docs", Bump: BumpNone},
+			want: BumpNone,
+		},
+		{
+			name: "default feat",
+			c:    CommitName{Type: CommitFeat},
+			want: BumpMinor,
+		},
+		{
+			name: "default other",
+			c:    CommitName{Type: "fix"},
+			want: BumpPatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.c.Level())
+		})
+	}
+}
+
+func TestCommitName_KeepCategory(t *testing.T) {
+	tests := []struct {
+		name string
+		c    CommitName
+		want KeepCategory
+	}{
+		{
+			name: "configured",
+			c:    CommitName{Type: "sec", Category: CategorySecurity},
+			want: CategorySecurity,
+		},
+		{
+			name: "default feat",
+			c:    CommitName{Type: CommitFeat},
+			want: CategoryAdded,
+		},
+		{
+			name: "default fix",
+			c:    CommitName{Type: _CommitFix},
+			want: CategoryFixed,
+		},
+		{
+			name: "default revert",
+			c:    CommitName{Type: _CommitRevert},
+			want: CategoryRemoved,
+		},
+		{
+			name: "default other",
+			c:    CommitName{Type: _CommitDocs},
+			want: CategoryChanged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.c.KeepCategory())
 		})
 	}
 }
@@ -452,10 +683,15 @@ func TestBumpFile_HasPositions(t *testing.T) {
 
 func TestBumpFile_validate(t *testing.T) {
 	type fields struct {
-		File   fsys.File
-		RegExp []string
-		Start  int
-		End    int
+		File      fsys.File
+		RegExp    []string
+		Start     int
+		End       int
+		Key       string
+		Keys      []string
+		Type      BumpType
+		XPath     string
+		Multiline bool
 	}
 
 	type args struct {
@@ -488,6 +724,243 @@ func TestBumpFile_validate(t *testing.T) {
 			},
 			assertion: assert.NoError,
 		},
+		{
+			name: "predefined toml",
+			fields: fields{
+				File: fsys.File("Cargo.toml"),
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "toml without key",
+			fields: fields{
+				File: fsys.File("config.toml"),
+				Type: BumpTypeTOML,
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "toml with regexp only",
+			fields: fields{
+				File:   fsys.File("config.toml"),
+				RegExp: []string{`^version = .+$`},
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "toml invalid key",
+			fields: fields{
+				File: fsys.File("config.toml"),
+				Key:  "app..version",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "yaml keys ok",
+			fields: fields{
+				File: fsys.File("Chart.yaml"),
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true, data: []byte("version: 1.0.0\nappVersion: 1.0.0\n")}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "yaml predefined keys without appVersion",
+			fields: fields{
+				File: fsys.File("Chart.yaml"),
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true, data: []byte("name: lib\ntype: library\nversion: 1.0.0\n")}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "yaml predefined keys not found",
+			fields: fields{
+				File: fsys.File("Chart.yaml"),
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true, data: []byte("name: lib\n")}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "yaml explicit key not found",
+			fields: fields{
+				File: fsys.File("Chart.yaml"),
+				Keys: []string{"version", "appVersion"},
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true, data: []byte("version: 1.0.0\n")}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "yaml key not found",
+			fields: fields{
+				File: fsys.File("openapi.yaml"),
+				Keys: []string{"info.version"},
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true, data: []byte("info:\n  title: API\n")}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "yaml key is not scalar",
+			fields: fields{
+				File: fsys.File("values.yml"),
+				Key:  "info",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true, data: []byte("info:\n  version: 1.0.0\n")}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "yaml parse error",
+			fields: fields{
+				File: fsys.File("Chart.yaml"),
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true, data: []byte("version: [1.0.0\n")}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "yaml read error",
+			fields: fields{
+				File: fsys.File("Chart.yaml"),
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true, readErr: assert.AnError}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "predefined xml",
+			fields: fields{
+				File: fsys.File("App.csproj"),
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "xml type without xpath",
+			fields: fields{
+				File: fsys.File("build.xml"),
+				Type: BumpTypeXML,
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "xml invalid xpath",
+			fields: fields{
+				File:  fsys.File("build.xml"),
+				Type:  BumpTypeXML,
+				XPath: "//project/version[1]",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "xml xpath ok",
+			fields: fields{
+				File:  fsys.File("build.xml"),
+				Type:  BumpTypeXML,
+				XPath: "/project/version",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "xpath without type",
+			fields: fields{
+				File:  fsys.File("build.xml"),
+				XPath: "/project/version",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "go identifier",
+			fields: fields{
+				File: fsys.File("internal/build/version.go"),
+				Key:  "internal/build.Version",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "go type without key",
+			fields: fields{
+				File: fsys.File("internal/build/version.go"),
+				Type: BumpTypeGo,
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "go invalid identifier",
+			fields: fields{
+				File: fsys.File("internal/build/version.go"),
+				Key:  "internal/build.1Version",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "invalid type",
+			fields: fields{
+				File: fsys.File("file"),
+				Type: "ini",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "key for not toml",
+			fields: fields{
+				File: fsys.File("file"),
+				Key:  "version",
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
 		{
 			name: "start > end",
 			fields: fields{
@@ -511,6 +984,52 @@ func TestBumpFile_validate(t *testing.T) {
 			},
 			assertion: assert.Error,
 		},
+		{
+			name: "regexp version group",
+			fields: fields{
+				File:      fsys.File("file"),
+				RegExp:    []string{`Version = "(?P<version>[^"]+)"`},
+				Multiline: true,
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "regexp other named group",
+			fields: fields{
+				File:   fsys.File("file"),
+				RegExp: []string{`Version = "(?P<ver>[^"]+)"`},
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "multiline without group",
+			fields: fields{
+				File:      fsys.File("file"),
+				RegExp:    []string{`^Version`},
+				Multiline: true,
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "multiline without regexp",
+			fields: fields{
+				File:      fsys.File("file"),
+				Multiline: true,
+			},
+			args: args{
+				rw: __newRWMock(__rwMockArgs{exists: true}),
+			},
+			assertion: assert.Error,
+		},
 		{
 			name: "regexp ok",
 			fields: fields{
@@ -526,10 +1045,15 @@ func TestBumpFile_validate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := BumpFile{
-				File:   tt.fields.File,
-				RegExp: tt.fields.RegExp,
-				Start:  tt.fields.Start,
-				End:    tt.fields.End,
+				File:      tt.fields.File,
+				RegExp:    tt.fields.RegExp,
+				Start:     tt.fields.Start,
+				End:       tt.fields.End,
+				Key:       tt.fields.Key,
+				Keys:      tt.fields.Keys,
+				Type:      tt.fields.Type,
+				XPath:     tt.fields.XPath,
+				Multiline: tt.fields.Multiline,
 			}
 
 			tt.assertion(t, f.validate(tt.args.rw))
@@ -555,6 +1079,15 @@ git:
   autoNextPatch: true
   allowDowngrades: true
   remoteUrl: https://github.com/klimby/version
+  push: true
+  pushRemote: upstream
+  sign: ssh
+  signKey: ~/.ssh/id_ed25519
+  commitMessage: '[REL-1] release {{.Version}}'
+  tagMessage: |-
+    Release {{.Version}}
+
+    {{.Notes}}
 changelog:
   generate: true
   file: CHANGELOG.md
@@ -571,6 +1104,16 @@ bump:
     end: 5
     regexp:
       - ^!\[Version.*$	
+projects:
+  - name: backend
+    bump:
+      - file: backend/version.go
+  - name: frontend
+    paths:
+      - web
+      - shared/ui
+    tagTemplate: "web-{{.Version}}"
+    changelog: web/CHANGES.md
 `
 
 func Test_newConfig(t *testing.T) {
@@ -604,6 +1147,12 @@ func Test_newConfig(t *testing.T) {
 			AutoGenerateNextPatch: true,
 			AllowDowngrades:       true,
 			RemoteURL:             "https://github.com/klimby/version",
+			Push:                  true,
+			PushRemote:            "upstream",
+			Sign:                  git.SignSSH,
+			SignKey:               "~/.ssh/id_ed25519",
+			CommitMessage:         "[REL-1] release {{.Version}}",
+			TagMessage:            "Release {{.Version}}\n\n{{.Notes}}",
 		},
 		ChangelogOptions: changelogOptions{
 			Generate:   true,
@@ -627,6 +1176,22 @@ func Test_newConfig(t *testing.T) {
 				RegExp: []string{`^!\[Version.*$`},
 			},
 		},
+		SubProjects: []Project{
+			{
+				Name:      "backend",
+				Paths:     []string{"backend"},
+				TagPrefix: "backend/v",
+				Changelog: "backend/CHANGELOG.md",
+				Bump:      []BumpFile{{File: "backend/version.go"}},
+			},
+			{
+				Name:        "frontend",
+				Paths:       []string{"web", "shared/ui"},
+				TagPrefix:   "frontend/v",
+				TagTemplate: "web-{{.Version}}",
+				Changelog:   "web/CHANGES.md",
+			},
+		},
 	}
 
 	tests := []struct {
@@ -736,9 +1301,169 @@ func Test_newConfig(t *testing.T) {
 					assert.Equal(t, tt.target.Bump[0].End, got.Bump[0].End, "bump End should be equal")
 					assert.Equal(t, tt.target.Bump[0].RegExp[0], got.Bump[0].RegExp[0], "bump RegExp should be equal")
 				}
+
+				assert.Equal(t, tt.target.SubProjects, got.Projects(), "projects should be equal")
 			}
 
 			tt.isFileConfig(t, got.IsFileConfig, "IsFileConfig wrong")
 		})
 	}
 }
+
+func Test_yamlQuote(t *testing.T) {
+	assert.Equal(t, template.HTML(`'const Version = "(?P<version>[^"]+)"'`), yamlQuote(`const Version = "(?P<version>[^"]+)"`))
+	assert.Equal(t, template.HTML(`'it''s'`), yamlQuote(`it's`))
+}
+
+func TestC_Generate_bump(t *testing.T) {
+	viper.Set(key.CfgFile, "config.yaml")
+
+	notRequired := false
+
+	bump := []BumpFile{
+		{File: "package.json", Exclude: []string{"x/**"}, Optional: true},
+		{File: "Chart.yaml", Keys: []string{"version", "appVersion"}, Required: &notRequired},
+		{File: "README.md", RegExp: []string{`^Version: "(?P<version>.+)"$`}, Start: 1, End: 10},
+	}
+
+	rw := __newRWMock(__rwMockArgs{})
+
+	c := &C{
+		Bump:        bump,
+		SubProjects: []Project{{Name: "web", Bump: bump}},
+		rw:          rw,
+	}
+
+	if !assert.NoError(t, c.Generate(), "Generate()") {
+		return
+	}
+
+	var got C
+
+	if !assert.NoError(t, yaml.Unmarshal(rw.rwc.buf.Bytes(), &got), "Unmarshal()") {
+		return
+	}
+
+	assert.Equal(t, bump, got.Bump, "Generate() root bump")
+
+	if assert.Len(t, got.SubProjects, 1, "Generate() projects") {
+		assert.Equal(t, got.Bump, got.SubProjects[0].Bump, "Generate() project bump")
+	}
+}
+
+func Test_yamlIndent(t *testing.T) {
+	assert.Equal(t, template.HTML("    a <b>\n\n    c"), yamlIndent("a <b>\n\nc", 4))
+}
+
+func TestBumpFile_GoIdent(t *testing.T) {
+	tests := []struct {
+		key      string
+		wantPkg  string
+		wantName string
+	}{
+		{key: "internal/build.Version", wantPkg: "internal/build", wantName: "Version"},
+		{key: "Version", wantPkg: "", wantName: "Version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			pkg, name := BumpFile{Key: tt.key}.GoIdent()
+
+			assert.Equal(t, tt.wantPkg, pkg, "GoIdent() pkg")
+			assert.Equal(t, tt.wantName, name, "GoIdent() name")
+		})
+	}
+}
+
+func TestBumpFile_IsRequired(t *testing.T) {
+	f, tr := false, true
+
+	assert.True(t, BumpFile{}.IsRequired(), "default")
+	assert.True(t, BumpFile{Required: &tr}.IsRequired(), "true")
+	assert.False(t, BumpFile{Required: &f}.IsRequired(), "false")
+}
+
+func TestBumpFile_expand(t *testing.T) {
+	viper.Set(key.WorkDir, "/repo")
+	defer viper.Reset()
+
+	tests := []struct {
+		name      string
+		file      BumpFile
+		rw        *__rwMock
+		want      []fsys.File
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "not a pattern",
+			file:      BumpFile{File: "package.json"},
+			rw:        __newRWMock(__rwMockArgs{exists: true}),
+			want:      []fsys.File{"package.json"},
+			assertion: assert.NoError,
+		},
+		{
+			name:      "optional file does not exist",
+			file:      BumpFile{File: "package.json", Optional: true},
+			rw:        __newRWMock(__rwMockArgs{exists: false}),
+			want:      nil,
+			assertion: assert.NoError,
+		},
+		{
+			name: "pattern with exclude",
+			file: BumpFile{File: "packages/*/package.json", Exclude: []string{"packages/legacy/**"}},
+			rw: __newRWMock(__rwMockArgs{glob: []string{
+				"/repo/packages/a/package.json",
+				"/repo/packages/legacy/package.json",
+				"/repo/packages/b/package.json",
+			}}),
+			want:      []fsys.File{"packages/a/package.json", "packages/b/package.json"},
+			assertion: assert.NoError,
+		},
+		{
+			name:      "pattern matches nothing",
+			file:      BumpFile{File: "**/Chart.yaml"},
+			rw:        __newRWMock(__rwMockArgs{}),
+			want:      nil,
+			assertion: assert.Error,
+		},
+		{
+			name:      "optional pattern matches nothing",
+			file:      BumpFile{File: "**/Chart.yaml", Optional: true},
+			rw:        __newRWMock(__rwMockArgs{}),
+			want:      []fsys.File{},
+			assertion: assert.NoError,
+		},
+		{
+			name:      "glob error",
+			file:      BumpFile{File: "**/Chart.yaml"},
+			rw:        __newRWMock(__rwMockArgs{globErr: assert.AnError}),
+			want:      nil,
+			assertion: assert.Error,
+		},
+		{
+			name:      "invalid exclude pattern",
+			file:      BumpFile{File: "**/Chart.yaml", Exclude: []string{"[a-"}},
+			rw:        __newRWMock(__rwMockArgs{}),
+			want:      nil,
+			assertion: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.file.expand(tt.rw)
+			tt.assertion(t, err)
+
+			var files []fsys.File
+			if got != nil {
+				files = make([]fsys.File, 0, len(got))
+			}
+
+			for _, f := range got {
+				files = append(files, f.File)
+			}
+
+			assert.Equal(t, tt.want, files)
+		})
+	}
+}
@@ -15,6 +15,16 @@ const (
 	AutoGenerateNextPatch = "autoGenerateNextPatch" // Auto generate next patch version, if version exists. Default: false.
 	AllowDowngrades       = "allowDowngrades"       // Allow version downgrades. Default: false.
 
+	GitTagPrefix   = "git.tagPrefix"   // Git tag prefix. Default: v.
+	GitTagTemplate = "git.tagTemplate" // Git tag name template (overrides tag prefix). Default: empty.
+	GitPush        = "git.push"        // Push release commit and tag to the remote. Default: false.
+	GitPushRemote  = "git.pushRemote"  // Remote name for push. Default: origin.
+	GitSign        = "git.sign"        // Sign release commit and tag: gpg, ssh. Default: empty (not signed).
+	GitSignKey     = "git.signKey"     // GPG keyring (required for gpg) or SSH key path. Default: empty (user.signingkey from git config).
+
+	GitCommitMessage = "git.commitMessage" // Release commit message template. Default: chore(release): {{.Version}}.
+	GitTagMessage    = "git.tagMessage"    // Release tag message template. Default: commit message and release notes.
+
 	GenerateChangelog   = "changelog.generate"   // Generate changelog. Default: true.
 	ChangelogFileName   = "changelog.fileName"   // Changelog file name. Default: CHANGELOG.md.
 	ChangelogTitle      = "changelog.title"      // Changelog title. Default: Changelog.
@@ -22,6 +32,15 @@ const (
 	ChangelogShowAuthor = "changelog.showAuthor" // Show author in changelog. Default: false.
 	ChangelogShowBody   = "changelog.showBody"   // Show body in changelog comment. Default: true.
 
+	ChangelogFoldPrerelease = "changelog.foldPrerelease" // Fold pre-release sections into the release section. Default: false.
+	ChangelogFormats        = "changelog.formats"        // Changelog file formats (markdown, json, yaml). Default: markdown.
+	ChangelogStyle          = "changelog.style"          // Markdown changelog style (conventional, keepachangelog). Default: conventional.
+
+	ChangelogTemplate       = "changelog.template"       // Version section template file. Default: empty (built-in template).
+	ChangelogHeaderTemplate = "changelog.headerTemplate" // Header template file. Default: empty (built-in template).
+
+	BuildMetadata = "buildMetadata" // Build metadata template for bump files. Default: empty.
+
 	Backup  = "backupChanged" // Backup changed files. Default: false.
 	Silent  = "silent"        // Silent mode from flags.
 	DryRun  = "dryRun"        // Dry run mode from flags.
@@ -29,6 +48,9 @@ const (
 	Verbose = "verbose"       // Verbose mode from flags.
 
 	Prepare = "prepare" // Prepare flag in next command.
+
+	Project      = "project"      // Project name from flags (monorepo). Default: empty (root project).
+	ProjectPaths = "projectPaths" // Selected project paths for filter commits. Default: empty (all commits).
 )
 
 // Viper testing keys.
@@ -1,5 +1,7 @@
 package config
 
+import "github.com/klimby/version/internal/service/git"
+
 // Default values.
 const (
 	_AppName               = "Version"
@@ -7,6 +9,10 @@ const (
 	_AllowCommitDirty      = false
 	_AutoGenerateNextPatch = false
 	_AllowDowngrades       = false
+	_GitTagPrefix          = "v"
+	_GitPushRemote         = "origin"
+	_GitCommitMessage      = git.DefaultCommitMessage
+	_GitTagMessage         = git.DefaultTagMessage
 
 	_GenerateChangelog   = true
 	_ChangelogFileName   = "CHANGELOG.md"
@@ -14,5 +20,8 @@ const (
 	_ChangelogShowAuthor = false
 	_ChangelogShowBody   = true
 
+	_ChangelogFoldPrerelease = false
+	_ChangelogStyle          = StyleConventional
+
 	DefaultConfigFile = "version.yaml"
 )
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/spf13/viper"
+)
+
+// Project is a sub-project with independent version (monorepo).
+type Project struct {
+	// Name is a project name for --project flag.
+	Name string `yaml:"name"`
+	// Paths is a list of project paths. Only commits, that change files in these paths, are used.
+	// Default: [name].
+	Paths []string `yaml:"paths"`
+	// TagPrefix is a git tag prefix. Default: <name>/v (backend/v1.2.0).
+	TagPrefix string `yaml:"tagPrefix"`
+	// TagTemplate is a git tag name template with {{.Version}} variable (overrides TagPrefix).
+	TagTemplate string `yaml:"tagTemplate"`
+	// Changelog is a project changelog file. Default: <first path>/CHANGELOG.md.
+	Changelog fsys.File `yaml:"changelog"`
+	// Bump is a list of project files for bump.
+	Bump []BumpFile `yaml:"bump"`
+}
+
+// withDefaults returns the project with default values for empty fields.
+func (p Project) withDefaults() Project {
+	if len(p.Paths) == 0 && p.Name != "" {
+		p.Paths = []string{p.Name}
+	}
+
+	if p.TagPrefix == "" && p.Name != "" {
+		p.TagPrefix = p.Name + "/v"
+	}
+
+	if p.Changelog.Empty() && len(p.Paths) > 0 {
+		p.Changelog = fsys.File(path.Join(p.Paths[0], _ChangelogFileName))
+	}
+
+	return p
+}
+
+// TagFormat returns the project git tag format.
+func (p Project) TagFormat() (git.TagFormat, error) {
+	return git.NewTagFormat(p.TagPrefix, p.TagTemplate)
+}
+
+// apply sets the project options to viper: tag format, changelog file and paths for filter commits.
+func (p Project) apply() {
+	viper.Set(key.Project, p.Name)
+	viper.Set(key.ProjectPaths, p.Paths)
+	viper.Set(key.GitTagPrefix, p.TagPrefix)
+	viper.Set(key.GitTagTemplate, p.TagTemplate)
+	viper.Set(key.ChangelogFileName, p.Changelog.String())
+}
+
+// validate validates the project and expands glob patterns of the bump files.
+func (p *Project) validate(rw bumpRW) error {
+	if p.Name == "" {
+		return fmt.Errorf(`%w: project name is empty`, errConfig)
+	}
+
+	if _, err := p.TagFormat(); err != nil {
+		return fmt.Errorf(`%w: project %s: %w`, errConfig, p.Name, err)
+	}
+
+	if p.Changelog.IsAbs() {
+		return fmt.Errorf(`%w: project %s changelog file name is absolute path`, errConfig, p.Name)
+	}
+
+	bump, err := expandBumpFiles(rw, p.Bump)
+	if err != nil {
+		return err
+	}
+
+	p.Bump = bump
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProject_withDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Project
+		want Project
+	}{
+		{
+			name: "defaults",
+			p:    Project{Name: "backend"},
+			want: Project{
+				Name:      "backend",
+				Paths:     []string{"backend"},
+				TagPrefix: "backend/v",
+				Changelog: "backend/CHANGELOG.md",
+			},
+		},
+		{
+			name: "configured",
+			p: Project{
+				Name:      "frontend",
+				Paths:     []string{"web", "shared"},
+				TagPrefix: "web-v",
+				Changelog: "CHANGES.md",
+			},
+			want: Project{
+				Name:      "frontend",
+				Paths:     []string{"web", "shared"},
+				TagPrefix: "web-v",
+				Changelog: "CHANGES.md",
+			},
+		},
+		{
+			name: "changelog from paths",
+			p: Project{
+				Name:  "frontend",
+				Paths: []string{"./web/"},
+			},
+			want: Project{
+				Name:      "frontend",
+				Paths:     []string{"./web/"},
+				TagPrefix: "frontend/v",
+				Changelog: "web/CHANGELOG.md",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.p.withDefaults())
+		})
+	}
+}
+
+func TestC_ValidateProjects(t *testing.T) {
+	tests := []struct {
+		name      string
+		projects  []Project
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name: "ok",
+			projects: []Project{
+				Project{Name: "backend"}.withDefaults(),
+				Project{Name: "frontend"}.withDefaults(),
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name:      "empty name",
+			projects:  []Project{{}},
+			assertion: assert.Error,
+		},
+		{
+			name: "duplicated name",
+			projects: []Project{
+				Project{Name: "backend"}.withDefaults(),
+				Project{Name: "backend"}.withDefaults(),
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "invalid tag template",
+			projects: []Project{
+				{Name: "backend", TagTemplate: "backend"},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "absolute changelog",
+			projects: []Project{
+				{Name: "backend", Changelog: "/backend/CHANGELOG.md"},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "invalid bump",
+			projects: []Project{
+				{Name: "backend", Bump: []BumpFile{{File: "backend/version.go"}}},
+			},
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := C{
+				IsFileConfig: true,
+				SubProjects:  tt.projects,
+				rw:           __newRWMock(__rwMockArgs{exists: false}),
+			}
+
+			tt.assertion(t, c.Validate())
+		})
+	}
+}
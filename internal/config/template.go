@@ -68,6 +68,32 @@ git:
   allowDowngrades: {{ .GitOptions.AllowDowngrades }}
   # Remote repository URL.
   remoteUrl: {{ .GitOptions.RemoteURL }}
+  # Git tag prefix. Tag name will be <prefix><version>, for example v1.2.3.
+  tagPrefix: "{{ .GitOptions.TagPrefix }}"
+  # Git tag name template with {{"{{"}}.Version{{"}}"}} variable. Overrides tagPrefix. Optional.
+  # Examples: "release-{{"{{"}}.Version{{"}}"}}", "api/v{{"{{"}}.Version{{"}}"}}".
+  # Tags, that do not match the format, are ignored.
+  tagTemplate: "{{ .GitOptions.TagTemplate }}"
+  # Push the current branch and the release tag to the remote after next command.
+  push: {{ .GitOptions.Push }}
+  # Remote name for push.
+  pushRemote: "{{ .GitOptions.PushRemote }}"
+  # Sign the release commit and tag: gpg or ssh. Empty - not signed.
+  sign: "{{ .GitOptions.Sign }}"
+  # GPG keyring (exported secret key, required for gpg) or SSH key path. Default for ssh: user.signingkey from git config.
+  signKey: "{{ .GitOptions.SignKey }}"
+  # Release commit message template (Go text/template).
+  # Template variables:
+  #   - {{"{{"}}.Version{{"}}"}} - release version (1.2.0);
+  #   - {{"{{"}}.Previous{{"}}"}} - previous version (1.1.0), empty for the first version;
+  #   - {{"{{"}}.Tag{{"}}"}} - release tag name (v1.2.0);
+  #   - {{"{{"}}.Date{{"}}"}} - release date (2024-01-31);
+  #   - {{"{{"}}.Notes{{"}}"}} - release notes (changelog section of the version).
+  # Example: "[REL-123] release {{"{{"}}.Version{{"}}"}}".
+  commitMessage: {{ quote .GitOptions.CommitMessage }}
+  # Release (annotated) tag message template with the same variables.
+  tagMessage: |-
+{{ indent .GitOptions.TagMessage 4 }}
 
 # Changelog settings.
 changelog:
@@ -87,24 +113,82 @@ changelog:
   showAuthor: {{ .ChangelogOptions.ShowAuthor }}
   # Show body in changelog comment.
   showBody: {{ .ChangelogOptions.ShowBody }}
+  # Fold pre-release sections (1.2.0-rc.1, 1.2.0-rc.2) into the release section (1.2.0).
+  # If true, then changelog will be regenerated on release of the pre-release version.
+  foldPrerelease: {{ .ChangelogOptions.FoldPrerelease }}
+  # Version section template file (Go text/template). Optional, default: built-in template.
+  # Data: .Version, .Previous, .Date, .BreakingChanges, .Blocks (.Name, .CommitType, .Commits).
+  # Functions: versionName, commitName, addIssueURL.
+  # Section should start with "##" header (new versions are inserted before the first "##" line).
+  # Run "version generate --config-file --templates" for dump default templates.
+  template: {{ .ChangelogOptions.Template.String }}
+  # Changelog header template file (Go text/template). Optional, default: built-in template.
+  # Data: .Title.
+  headerTemplate: {{ .ChangelogOptions.HeaderTemplate.String }}
+  # Markdown changelog style: conventional or keepachangelog (https://keepachangelog.com). Default: conventional.
+  # Keepachangelog style has "## [Unreleased]" section, Added/Changed/Deprecated/Removed/Fixed/Security categories
+  # and compare links at the bottom. Unreleased entries are moved into the new version section on "next" command.
+  style: {{ .ChangelogOptions.Style }}
+  # Changelog file formats: markdown, json, yaml. Default: markdown.
+  # Json and yaml files have changelog file name with .json and .yaml extensions (CHANGELOG.json).
+  # They contain versions, dates, compare URLs, breaking changes and commits by types.
+  formats:
+  {{- range .ChangelogOptions.Formats }}
+    - {{ . }}
+  {{- end}}
   # Commit types for changelog.
   # Type - commit type, value - commit type name.
+  # Bump - version level, required by commit type for "next --auto": major, minor, patch or none (no release).
+  # Optional, default: minor for feat, patch for others. Breaking changes always require major level.
+  # Category - Keep a Changelog category for keepachangelog style: Added, Changed, Deprecated, Removed, Fixed, Security.
+  # Optional, default: Added for feat, Fixed for fix, Removed for revert, Changed for others.
   # If empty, then all commit types will be hidden, except Breaking Changes.
   commitTypes:
   {{- range .ChangelogOptions.CommitTypes }}
     - type: "{{ .Type }}"
       name: "{{ .Name }}"
+{{- if .Bump }}
+      bump: "{{ .Bump }}"
+{{- end}}
+{{- if .Category }}
+      category: "{{ .Category }}"
+{{- end}}
   {{- end}}
 
 # Bump files.
-# Change version in files. Version will be changed with format: <digital>.<digital>.<digital>
+# Change version in files. Version is a SemVer string with optional "v" prefix: 1.2.3, v1.2.3-rc.1+build.5.
+# Only the current version (from git tags) is replaced, "v" prefix is kept.
 # Every entry has format:
-# - file: file patch
+# - file: file patch or glob pattern (packages/*/package.json, **/Chart.yaml)
+#   exclude: glob patterns for files, excluded from the file pattern matches (optional, array)
+#   optional: the file may not exist or the pattern may match no files (optional, default false)
 #   start: number (optional)
 #   end: number (optional)
-#   regexp: regular expression for string search (optional, array)
+#   regexp: regular expression for string search (optional, array).
+#     If regexp has named group (?P<version>...), then only the group is replaced.
+#   multiline: apply regexps with named group to the text from start to end, not to every line (optional)
+#   anyVersion: replace any version, not only the current one (optional, default false)
+#   required: version is not created, if the file is not changed (optional, default true)
+#   key: dotted path to version field in TOML or YAML file (optional),
+#     or package-qualified identifier in Go file (internal/build.Version)
+#   keys: dotted paths to version fields in TOML or YAML file (optional, array)
+#   type: file type: toml, yaml, xml or go (optional, by default detected by file name)
+#   xpath: path to version element in XML file (optional, for example /project/version)
 # 
 # If file is composer.json or package.json, then regexp and start/end are ignored.
+# Lock files package-lock.json, npm-shrinkwrap.json and composer.lock near the changed file are bumped too
+# (only root package version, not dependencies).
+# If file is TOML file (*.toml) or YAML file (*.yaml, *.yml) with keys, then only values of the keys are changed,
+# comments, formatting and ordering are kept.
+# Default key for Cargo.toml is package.version (or workspace.package.version),
+# for pyproject.toml - project.version (or tool.poetry.version),
+# for Chart.yaml - version and appVersion, for openapi.yaml (swagger.yaml) - info.version.
+# If file is XML file (pom.xml, *.csproj or file with xpath), then only text of the element is changed.
+# Default xpath for pom.xml is /project/version, for *.csproj - /Project/PropertyGroup/Version.
+# If file is Go file (*.go with key), then string literal of the constant or variable is changed.
+#
+# If required file is not changed or any file bump error, then version is not created.
+# Set "required: false" for files, that can be not changed.
 #
 # Examples:
 # bump:
@@ -130,18 +214,146 @@ changelog:
 #
 # All strings from file, from 0 to 100, that match regexp will be replaced with new version.
 #
-bump: 
+# bump:
+#   - file: internal/build/version.go
+#     regexp:
+#       - const Version = "(?P<version>[^"]+)"
+#
+# Only value of the Version constant will be replaced with new version.
+#
+# bump:
+#   - file: internal/build/version.go
+#     key: internal/build.Version
+#
+# The same with Go parser: the string literal of Version constant will be replaced with new version.
+#
+# bump:
+#   - file: Cargo.toml
+#   - file: tools/config.toml
+#     key: app.version
+#   - file: api/spec.yaml
+#     keys:
+#       - info.version
+#       - x-api.version
+#
+# Version will be changed in [package] table of Cargo.toml, in [app] table of tools/config.toml
+# and in info.version and x-api.version fields of api/spec.yaml.
+#
+# bump:
+#   - file: pom.xml
+#   - file: build/version.props
+#     type: xml
+#     xpath: /Project/PropertyGroup/VersionPrefix
+#
+# Version will be changed in <version> element of <project> in pom.xml and in <VersionPrefix> of build/version.props.
+#
+# bump:
+#   - file: packages/*/package.json
+#     exclude:
+#       - packages/legacy/**
+#   - file: "**/Chart.yaml"
+#     optional: true
+#
+# Version will be changed in package.json of every package (except legacy) and in all Chart.yaml files, if they exist.
+#
+bump: {{- template "bump" bumps 2 .Bump }}
+
+# Build metadata for version in bump files (template). Optional.
+# Git tag and changelog always use version without build metadata.
+# Template variables:
+#   - {{"{{"}}.Hash{{"}}"}} - HEAD commit hash;
+#   - {{"{{"}}.ShortHash{{"}}"}} - HEAD commit short hash (7 symbols);
+#   - {{"{{"}}.Date{{"}}"}} - current date in format YYYYMMDD.
+# Example:
+# buildMetadata: "sha.{{"{{"}}.ShortHash{{"}}"}}"
+# In this example, version in bump files will be 1.2.3+sha.abc1234.
+buildMetadata: "{{ .BuildMetadata }}"
+
+# Sub-projects with independent versions (monorepo).
+# Use "next --project <name>" for version the project.
+# Every entry has format:
+# - name: project name. Required.
+#   paths: project paths. Only commits, that change files in these paths, are used (optional, default: [<name>]).
+#   tagPrefix: git tag prefix (optional, default: <name>/v).
+#   tagTemplate: git tag name template with {{"{{"}}.Version{{"}}"}} variable (optional, overrides tagPrefix).
+#   changelog: project changelog file (optional, default: <first path>/CHANGELOG.md).
+#   bump: project files for bump (optional, format as in root bump section).
+#
+# Example:
+# projects:
+#   - name: backend
+#     paths:
+#       - backend
+#     bump:
+#       - file: backend/version.go
+#
+# In this example, "next --project backend --minor" creates tag backend/v1.3.0.
+projects:
+{{- range .SubProjects }}
+  - name: {{ .Name }}
+{{- if .Paths }}
+    paths:
+{{- range .Paths }}
+      - {{ . }}
+{{- end}}
+{{- end}}
+    tagPrefix: "{{ .TagPrefix }}"
+{{- if .TagTemplate }}
+    tagTemplate: "{{ .TagTemplate }}"
+{{- end}}
+    changelog: {{ .Changelog.String }}
+{{- if .Bump }}
+    bump: {{- template "bump" bumps 6 .Bump }}
+{{- end}}
+{{- end}}
+
+{{- define "bump" }}
+{{- $i := .Indent }}
 {{- range $value := .Bump }}
-  - file: {{ $value.File.String }}
+{{ $i }}- file: {{ $value.File.String }}
+{{- if $value.Exclude }}
+{{ $i }}  exclude:
+{{- range $value.Exclude }}
+{{ $i }}    - {{ quote . }}
+{{- end}}
+{{- end}}
+{{- if $value.Optional }}
+{{ $i }}  optional: true
+{{- end}}
+{{- if $value.Key }}
+{{ $i }}  key: {{ $value.Key }}
+{{- end}}
+{{- if $value.Keys }}
+{{ $i }}  keys:
+{{- range $value.Keys }}
+{{ $i }}    - {{ . }}
+{{- end}}
+{{- end}}
+{{- if $value.Type }}
+{{ $i }}  type: {{ $value.Type }}
+{{- end}}
+{{- if $value.XPath }}
+{{ $i }}  xpath: {{ $value.XPath }}
+{{- end}}
+{{- if $value.AnyVersion }}
+{{ $i }}  anyVersion: true
+{{- end}}
+{{- if not $value.IsRequired }}
+{{ $i }}  required: false
+{{- end}}
+{{- if $value.Multiline }}
+{{ $i }}  multiline: true
+{{- end}}
 {{- if $value.HasPositions }}
-    start: {{ $value.Start }}
-    end: {{ $value.End }}
+{{ $i }}  start: {{ $value.Start }}
+{{ $i }}  end: {{ $value.End }}
 {{- end}}
 {{- if $value.RegExp }}
-    regexp: 
+{{ $i }}  regexp:
 {{- range $value.RegExp }}
-      - {{ . }}
+{{ $i }}    - {{ quote . }}
+{{- end}}
+{{- end}}
 {{- end}}
 {{- end}}
-{{- end}}	
 `
@@ -20,6 +20,10 @@ type __rwMockArgs struct {
 	// Exists
 	exists bool
 
+	// Glob matches and error
+	glob    []string
+	globErr error
+
 	// data init for __RWC.buf
 	data []byte
 }
@@ -39,6 +43,7 @@ func __newRWMock(a __rwMockArgs) *__rwMock {
 	r.On("Write", mock.Anything, mock.Anything).Return(r.rwc, a.writeErr)
 	r.On("Read", mock.Anything).Return(r.rwc, a.readErr)
 	r.On("Exists").Return(a.exists)
+	r.On("Glob", mock.Anything).Return(a.glob, a.globErr)
 
 	return r
 }
@@ -66,6 +71,12 @@ func (rw *__rwMock) Exists(_ string) bool {
 	return ret.Bool(0)
 }
 
+func (rw *__rwMock) Glob(pattern string) ([]string, error) {
+	ret := rw.Called(pattern)
+
+	return ret.Get(0).([]string), ret.Error(1)
+}
+
 // __RWC - simple structure io.ReadWriteCloser.
 type __RWC struct {
 	buf        bytes.Buffer
@@ -22,12 +22,19 @@ type Options struct {
 	AllowCommitDirty      bool
 	AutoGenerateNextPatch bool
 	AllowDowngrades       bool
+	GitTagPrefix          string
+	GitTagTemplate        string
+	GitPushRemote         string
+	GitCommitMessage      string
+	GitTagMessage         string
 	GenerateChangelog     bool
 	ChangelogFileName     string
 	ChangelogTitle        string
 	ChangelogIssueHref    string
 	ChangelogShowAuthor   bool
 	ChangelogShowBody     bool
+	ChangelogFoldPre      bool
+	ChangelogStyle        string
 	Silent                bool
 	DryRun                bool
 	Backup                bool
@@ -45,12 +52,18 @@ func Init(opts ...func(options *Options)) {
 		AllowCommitDirty:      _AllowCommitDirty,
 		AutoGenerateNextPatch: _AutoGenerateNextPatch,
 		AllowDowngrades:       _AllowDowngrades,
+		GitTagPrefix:          _GitTagPrefix,
+		GitPushRemote:         _GitPushRemote,
+		GitCommitMessage:      _GitCommitMessage,
+		GitTagMessage:         _GitTagMessage,
 		GenerateChangelog:     _GenerateChangelog,
 		ChangelogFileName:     _ChangelogFileName,
 		ChangelogTitle:        _ChangelogTitle,
 		ConfigFile:            DefaultConfigFile,
 		ChangelogShowAuthor:   _ChangelogShowAuthor,
 		ChangelogShowBody:     _ChangelogShowBody,
+		ChangelogFoldPre:      _ChangelogFoldPrerelease,
+		ChangelogStyle:        _ChangelogStyle.String(),
 	}
 
 	for _, opt := range opts {
@@ -67,6 +80,11 @@ func Init(opts ...func(options *Options)) {
 	viper.Set(key.AllowCommitDirty, co.AllowCommitDirty)
 	viper.Set(key.AutoGenerateNextPatch, co.AutoGenerateNextPatch)
 	viper.Set(key.AllowDowngrades, co.AllowDowngrades)
+	viper.Set(key.GitTagPrefix, co.GitTagPrefix)
+	viper.Set(key.GitTagTemplate, co.GitTagTemplate)
+	viper.Set(key.GitPushRemote, co.GitPushRemote)
+	viper.Set(key.GitCommitMessage, co.GitCommitMessage)
+	viper.Set(key.GitTagMessage, co.GitTagMessage)
 
 	viper.Set(key.GenerateChangelog, co.GenerateChangelog)
 	viper.Set(key.ChangelogFileName, co.ChangelogFileName)
@@ -74,6 +92,8 @@ func Init(opts ...func(options *Options)) {
 	viper.Set(key.ChangelogIssueURL, co.ChangelogIssueHref)
 	viper.Set(key.ChangelogShowAuthor, co.ChangelogShowAuthor)
 	viper.Set(key.ChangelogShowBody, co.ChangelogShowBody)
+	viper.Set(key.ChangelogFoldPrerelease, co.ChangelogFoldPre)
+	viper.Set(key.ChangelogStyle, co.ChangelogStyle)
 
 	if co.ConfigFile != DefaultConfigFile {
 		viper.Set(key.CfgFile, co.ConfigFile)
@@ -162,11 +182,38 @@ func Load(opts ...func(options *LoadArgs)) (C, error) {
 			viper.Set(key.AllowDowngrades, c.GitOptions.AllowDowngrades)
 		}
 
+		viper.Set(key.GitTagPrefix, c.GitOptions.TagPrefix)
+		viper.Set(key.GitTagTemplate, c.GitOptions.TagTemplate)
+
+		if c.GitOptions.Push {
+			viper.Set(key.GitPush, c.GitOptions.Push)
+		}
+
+		if c.GitOptions.PushRemote != "" {
+			viper.Set(key.GitPushRemote, c.GitOptions.PushRemote)
+		}
+
+		viper.Set(key.GitSign, c.GitOptions.Sign.String())
+		viper.Set(key.GitSignKey, c.GitOptions.SignKey)
+
+		if c.GitOptions.CommitMessage != "" {
+			viper.Set(key.GitCommitMessage, c.GitOptions.CommitMessage)
+		}
+
+		if c.GitOptions.TagMessage != "" {
+			viper.Set(key.GitTagMessage, c.GitOptions.TagMessage)
+		}
+
 		viper.Set(key.GenerateChangelog, c.ChangelogOptions.Generate)
 		viper.Set(key.ChangelogFileName, c.ChangelogOptions.FileName)
 		viper.Set(key.ChangelogTitle, c.ChangelogOptions.Title)
 		viper.Set(key.ChangelogShowAuthor, c.ChangelogOptions.ShowAuthor)
 		viper.Set(key.ChangelogShowBody, c.ChangelogOptions.ShowBody)
+		viper.Set(key.ChangelogFoldPrerelease, c.ChangelogOptions.FoldPrerelease)
+		viper.Set(key.ChangelogTemplate, c.ChangelogOptions.Template.String())
+		viper.Set(key.ChangelogHeaderTemplate, c.ChangelogOptions.HeaderTemplate.String())
+		viper.Set(key.ChangelogFormats, formatStrings(c.ChangelogOptions.Formats))
+		viper.Set(key.ChangelogStyle, c.ChangelogOptions.Style.String())
 
 		if c.Backup {
 			viper.Set(key.Backup, c.Backup)
@@ -179,6 +226,16 @@ func Load(opts ...func(options *LoadArgs)) (C, error) {
 		if c.GitOptions.RemoteURL != "" {
 			viper.Set(key.RemoteURL, c.GitOptions.RemoteURL)
 		}
+
+		if c.BuildMetadata != "" {
+			viper.Set(key.BuildMetadata, c.BuildMetadata)
+		}
+	}
+
+	if name := viper.GetString(key.Project); name != "" {
+		if err := c.selectProject(name); err != nil {
+			return c, err
+		}
 	}
 
 	return c, nil
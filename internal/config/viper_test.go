@@ -16,8 +16,9 @@ func TestLoad(t *testing.T) {
 	data := []byte(__fakeConfigYaml)
 
 	tests := []struct {
-		name string
-		args args
+		name    string
+		args    args
+		project string
 
 		wantErr assert.ErrorAssertionFunc
 	}{
@@ -28,6 +29,22 @@ func TestLoad(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "project",
+			args: args{
+				rw: __newRWMock(__rwMockArgs{data: data}),
+			},
+			project: "backend",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "unknown project",
+			args: args{
+				rw: __newRWMock(__rwMockArgs{data: data}),
+			},
+			project: "unknown",
+			wantErr: assert.Error,
+		},
 		{
 			name: "load error",
 			args: args{
@@ -38,6 +55,14 @@ func TestLoad(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.Project, tt.project)
+
+			defer func() {
+				viper.Set(key.Project, "")
+				viper.Set(key.ProjectPaths, nil)
+				viper.Set(key.GitTagPrefix, _GitTagPrefix)
+			}()
+
 			got, err := Load(func(options *LoadArgs) {
 				options.RW = tt.args.rw
 			})
@@ -56,6 +81,17 @@ func TestLoad(t *testing.T) {
 			assert.True(t, viper.GetBool(key.Backup), "Backup")
 
 			assert.Equal(t, got.ChangelogOptions.Generate, viper.GetBool(key.GenerateChangelog), "GenerateChangelog")
+
+			if tt.project != "" {
+				assert.Equal(t, "backend/CHANGELOG.md", viper.GetString(key.ChangelogFileName), "project ChangelogFileName")
+				assert.Equal(t, "backend/v", viper.GetString(key.GitTagPrefix), "project GitTagPrefix")
+				assert.Equal(t, []string{"backend"}, viper.GetStringSlice(key.ProjectPaths), "project ProjectPaths")
+				assert.Equal(t, []BumpFile{{File: "backend/version.go"}}, got.BumpFiles(), "project BumpFiles")
+
+				return
+			}
+
+			assert.Equal(t, got.Bump, got.BumpFiles(), "BumpFiles")
 			assert.Equal(t, got.ChangelogOptions.FileName.String(), viper.GetString(key.ChangelogFileName), "ChangelogFileName")
 			assert.Equal(t, got.ChangelogOptions.Title, viper.GetString(key.ChangelogTitle), "ChangelogFileName")
 			assert.Equal(t, got.ChangelogOptions.ShowAuthor, viper.GetBool(key.ChangelogShowAuthor), "ChangelogShowAuthor")
@@ -7,6 +7,7 @@ import (
 
 	"github.com/klimby/version/internal/config"
 	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/backup"
 	"github.com/klimby/version/internal/service/bump"
 	"github.com/klimby/version/internal/service/changelog"
 	"github.com/klimby/version/internal/service/console"
@@ -35,6 +36,9 @@ type container struct {
 
 	// Bump object singleton.
 	Bump *bump.B
+
+	// Journal records files, changed by bump and changelog, for rollback.
+	Journal *fsys.Journal
 }
 
 // Init initializes the container.
@@ -87,14 +91,24 @@ func (c *container) Init() error {
 		console.Warn(err.Error())
 	}
 
+	c.Journal = fsys.NewJournal()
+
+	bcp := backup.New(func(arg *backup.Args) {
+		arg.RW = c.Journal
+	})
+
 	c.ChangelogGenerator = changelog.New(func(options *changelog.Args) {
 		options.Repo = c.Repo
+		options.RW = c.Journal
+		options.Backup = bcp
 		options.ConfigFile = fsys.File(viper.GetString(key.ChangelogFileName))
 		options.CommitNames = cfg.CommitTypes()
 	})
 
 	c.Bump = bump.New(func(arg *bump.Args) {
 		arg.Repo = c.Repo
+		arg.RW = c.Journal
+		arg.Backup = bcp
 	})
 
 	return nil
@@ -2,20 +2,77 @@
 package bump
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"slices"
 
 	"github.com/klimby/version/internal/config"
 	"github.com/klimby/version/internal/config/key"
 	"github.com/klimby/version/internal/service/backup"
 	"github.com/klimby/version/internal/service/console"
 	"github.com/klimby/version/internal/service/fsys"
-	"github.com/klimby/version/pkg/convert"
 	"github.com/klimby/version/pkg/version"
 	"github.com/spf13/viper"
 )
 
+var (
+	// ErrRequired is returned, if the required file is not bumped.
+	ErrRequired = errors.New("required bump file is not updated")
+	// ErrBump is returned, if the file processing is failed (required or not).
+	ErrBump = errors.New("bump file error")
+)
+
+// Result is a result of the file bump.
+type Result struct {
+	// File is a bump file.
+	File fsys.File
+	// Changed is true, if the file is changed.
+	Changed bool
+	// Required is true, if the file must be changed.
+	Required bool
+	// Err is a bump error.
+	Err error
+}
+
+// Failed returns true, if the file processing is failed or the file is required and not changed.
+// Not existing file is failed only if it is required (optional files are checked by config).
+func (r Result) Failed() bool {
+	if r.Err != nil && !errors.Is(r.Err, fs.ErrNotExist) {
+		return true
+	}
+
+	return r.Required && !r.Changed
+}
+
+// Results is a list of the file bump results.
+type Results []Result
+
+// Err returns error, if some files are failed or required files are not changed.
+func (rs Results) Err() error {
+	var errs []error
+
+	for _, r := range rs {
+		if !r.Failed() {
+			continue
+		}
+
+		switch {
+		case r.Err != nil && r.Required:
+			errs = append(errs, fmt.Errorf("%w: %w: %w", ErrRequired, ErrBump, r.Err))
+		case r.Err != nil:
+			errs = append(errs, fmt.Errorf("%w: %w", ErrBump, r.Err))
+		default:
+			errs = append(errs, fmt.Errorf("%w: file %s is not changed", ErrRequired, r.File.String()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // B bump files by version.
 type B struct {
 	rw      readWriter
@@ -26,6 +83,7 @@ type B struct {
 
 type gitRepo interface {
 	Add(files ...fsys.File) error
+	Current() (version.V, error)
 }
 
 type backupSrv interface {
@@ -35,11 +93,17 @@ type backupSrv interface {
 type readWriter interface {
 	Read(string) (io.ReadCloser, error)
 	Write(patch string, flag int) (io.WriteCloser, error)
+	Exists(p string) bool
 }
 
 type contentProcessor interface {
-	CustomFile(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error)
-	PredefinedJSON(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error)
+	CustomFile(r io.Reader, bmp config.BumpFile, current, v version.V) ([]string, bool, error)
+	PredefinedJSON(r io.Reader, bmp config.BumpFile, current, v version.V) ([]string, bool, error)
+	TOML(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error)
+	YAML(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error)
+	XML(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error)
+	Go(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error)
+	Lockfile(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error)
 }
 
 // Args is a Bump arguments.
@@ -70,31 +134,67 @@ func New(args ...func(arg *Args)) *B {
 	}
 }
 
-// Apply bumps files.
-func (b B) Apply(bumps []config.BumpFile, v version.V) {
-	for _, bmp := range bumps {
-		if err := b.bcp.Create(bmp.File.Path()); err != nil {
-			console.Error(fmt.Sprintf("create backup file %s error: %s", bmp.File.String(), err.Error()))
-		}
+// Apply bumps files and returns results for every file.
+// In custom and JSON files only the current version (from git tags) is replaced.
+// If package.json or composer.json is changed, then existing lock files are bumped too (not required).
+// Errors are printed as warnings, use Results.Err for check required files.
+func (b B) Apply(bumps []config.BumpFile, v version.V) Results {
+	current, err := b.repo.Current()
+	if err != nil {
+		console.Warn(fmt.Sprintf("get current version error: %s", err.Error()))
+	}
 
-		changed, err := b.applyToFile(bmp, v)
-		if err != nil {
-			console.Warn(fmt.Sprintf("bump file %s error: %s", bmp.File.String(), err.Error()))
+	results := make(Results, 0, len(bumps))
 
+	for _, bmp := range bumps {
+		res := b.apply(bmp, current, v)
+		results = append(results, res)
+
+		if !res.Changed {
 			continue
 		}
 
-		if changed {
-			if err := b.repo.Add(bmp.File); err != nil {
-				console.Warn(fmt.Sprintf("add file %s to git error: %s", bmp.File.String(), err.Error()))
+		for _, lock := range bmp.Lockfiles() {
+			if !b.rw.Exists(lock.Path()) || slices.ContainsFunc(bumps, func(f config.BumpFile) bool {
+				return f.File.Path() == lock.Path()
+			}) {
+				continue
 			}
+
+			results = append(results, b.apply(config.BumpFile{File: lock, Required: new(bool)}, current, v))
+		}
+	}
+
+	return results
+}
+
+// apply creates backup, bumps file and adds it to git.
+func (b B) apply(bmp config.BumpFile, current, v version.V) Result {
+	if err := b.bcp.Create(bmp.File.Path()); err != nil {
+		console.Error(fmt.Sprintf("create backup file %s error: %s", bmp.File.String(), err.Error()))
+	}
+
+	res := Result{File: bmp.File, Required: bmp.IsRequired()}
+	res.Changed, res.Err = b.applyToFile(bmp, current, v)
+
+	if res.Err != nil {
+		console.Warn(fmt.Sprintf("bump file %s error: %s", bmp.File.String(), res.Err.Error()))
+
+		return res
+	}
+
+	if res.Changed {
+		if err := b.repo.Add(bmp.File); err != nil {
+			console.Warn(fmt.Sprintf("add file %s to git error: %s", bmp.File.String(), err.Error()))
 		}
 	}
+
+	return res
 }
 
 // applyToFile bumps file.
-func (b B) applyToFile(bmp config.BumpFile, v version.V) (bool, error) {
-	content, changed, err := b.read(bmp, v)
+func (b B) applyToFile(bmp config.BumpFile, current, v version.V) (bool, error) {
+	content, lay, changed, err := b.read(bmp, current, v)
 	if err != nil {
 		return false, fmt.Errorf("bump file %s error: %w", bmp.File.String(), err)
 	}
@@ -110,7 +210,7 @@ func (b B) applyToFile(bmp config.BumpFile, v version.V) (bool, error) {
 	}
 
 	if !viper.GetBool(key.DryRun) {
-		if err := b.write(bmp.File.Path(), content); err != nil {
+		if err := b.write(bmp.File.Path(), lay.bytes(content)); err != nil {
 			return false, fmt.Errorf("write file %s error: %w", bmp.File.String(), err)
 		}
 	}
@@ -120,11 +220,12 @@ func (b B) applyToFile(bmp config.BumpFile, v version.V) (bool, error) {
 	return changed, nil
 }
 
-// read reads file.
-func (b B) read(bmp config.BumpFile, v version.V) (_ []string, changed bool, err error) {
+// read reads file and processes content.
+// Returns content lines without BOM and line endings and the file layout for write.
+func (b B) read(bmp config.BumpFile, current, v version.V) (_ []string, _ layout, changed bool, err error) {
 	r, err := b.rw.Read(bmp.File.Path())
 	if err != nil {
-		return nil, false, fmt.Errorf("open file %s error: %w", bmp.File.Path(), err)
+		return nil, layout{}, false, fmt.Errorf("open file %s error: %w", bmp.File.Path(), err)
 	}
 
 	defer func() {
@@ -135,15 +236,40 @@ func (b B) read(bmp config.BumpFile, v version.V) (_ []string, changed bool, err
 		}
 	}()
 
-	if bmp.IsPredefinedJSON() {
-		return b.process.PredefinedJSON(r, bmp, v)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, layout{}, false, fmt.Errorf("read file %s error: %w", bmp.File.Path(), err)
 	}
 
-	return b.process.CustomFile(r, bmp, v)
+	lay, data := newLayout(data)
+
+	content, changed, err := b.processContent(bytes.NewReader(data), bmp, current, v)
+
+	return content, lay, changed, err
+}
+
+// processContent processes content by file type.
+func (b B) processContent(r io.Reader, bmp config.BumpFile, current, v version.V) ([]string, bool, error) {
+	switch {
+	case bmp.IsGo():
+		return b.process.Go(r, bmp, v)
+	case bmp.IsXML():
+		return b.process.XML(r, bmp, v)
+	case bmp.IsTOML():
+		return b.process.TOML(r, bmp, v)
+	case bmp.IsYAML():
+		return b.process.YAML(r, bmp, v)
+	case bmp.IsLockfile():
+		return b.process.Lockfile(r, bmp, v)
+	case bmp.IsPredefinedJSON():
+		return b.process.PredefinedJSON(r, bmp, current, v)
+	}
+
+	return b.process.CustomFile(r, bmp, current, v)
 }
 
 // write writes content to file.
-func (b B) write(patch string, content []string) (err error) {
+func (b B) write(patch string, content []byte) (err error) {
 	w, err := b.rw.Write(patch, os.O_WRONLY|os.O_TRUNC)
 	if err != nil {
 		return fmt.Errorf("open file %s error: %w", patch, err)
@@ -157,11 +283,8 @@ func (b B) write(patch string, content []string) (err error) {
 		}
 	}()
 
-	for _, line := range content {
-		_, err := w.Write(convert.S2B(line + "\n"))
-		if err != nil {
-			return fmt.Errorf("write file %s line %s error: %w", patch, line, err)
-		}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("write file %s error: %w", patch, err)
 	}
 
 	return nil
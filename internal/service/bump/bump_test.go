@@ -1,7 +1,9 @@
 package bump
 
 import (
+	"fmt"
 	"io"
+	"io/fs"
 	"testing"
 
 	"github.com/klimby/version/internal/config"
@@ -27,6 +29,8 @@ func TestB_Apply(t *testing.T) {
 		},
 	}
 
+	notRequired := false
+
 	type fields struct {
 		rw      *__rwMock
 		process *__processMock
@@ -48,8 +52,10 @@ func TestB_Apply(t *testing.T) {
 	tests := []struct {
 		name        string
 		fields      fields
+		bmps        []config.BumpFile
 		wantCall    wantCall
 		wantConsole wantConsole
+		wantErr     assert.ErrorAssertionFunc
 	}{
 		{
 			name: "normal",
@@ -82,6 +88,7 @@ func TestB_Apply(t *testing.T) {
 				applyToFile: true,
 				repo:        false,
 			},
+			wantErr: assert.Error,
 		},
 		{
 			name: "backup error",
@@ -119,6 +126,69 @@ func TestB_Apply(t *testing.T) {
 			wantConsole: wantConsole{
 				applyWarning: true,
 			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "not required file error",
+			fields: fields{
+				rw: __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{
+					err: assert.AnError,
+				}),
+				repo: __newRepoMock(nil),
+				bcp:  __newBackupSrvMock(nil),
+			},
+			bmps: []config.BumpFile{
+				{File: fsys.File("composer.json"), Required: &notRequired},
+			},
+			wantCall: wantCall{
+				applyToFile: true,
+			},
+			wantConsole: wantConsole{
+				applyWarning: true,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "not required file not exists",
+			fields: fields{
+				rw: __newRWMock(__rwMockArgs{readErr: fs.ErrNotExist}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{
+					data:    []string{"foo", "bar"},
+					changed: true,
+				}),
+				repo: __newRepoMock(nil),
+				bcp:  __newBackupSrvMock(nil),
+			},
+			bmps: []config.BumpFile{
+				{File: fsys.File("composer.json"), Required: &notRequired},
+			},
+			wantCall: wantCall{
+				applyToFile: true,
+			},
+			wantConsole: wantConsole{
+				applyWarning: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "not required file no change",
+			fields: fields{
+				rw: __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{
+					data:    []string{"foo", "bar"},
+					changed: false,
+				}),
+				repo: __newRepoMock(nil),
+				bcp:  __newBackupSrvMock(nil),
+			},
+			bmps: []config.BumpFile{
+				{File: fsys.File("composer.json"), Required: &notRequired},
+			},
+			wantCall: wantCall{
+				applyToFile: true,
+			},
+			wantErr: assert.NoError,
 		},
 		{
 			name: "repo error",
@@ -158,7 +228,15 @@ func TestB_Apply(t *testing.T) {
 				options.Colorize = false
 			})
 
-			b.Apply(bmps, ver)
+			if tt.bmps == nil {
+				tt.bmps = bmps
+			}
+
+			if tt.wantErr == nil {
+				tt.wantErr = assert.NoError
+			}
+
+			tt.wantErr(t, b.Apply(tt.bmps, ver).Err(), "Apply()")
 
 			if tt.wantCall.applyToFile {
 				tt.fields.rw.AssertCalled(t, "Read", mock.Anything)
@@ -197,6 +275,98 @@ func TestB_Apply(t *testing.T) {
 	}
 }
 
+func TestB_Apply_goNotRequired(t *testing.T) {
+	notRequired := false
+
+	b := New(func(args *Args) {
+		args.RW = __newRWMock(__rwMockArgs{data: []byte("package build\n\nconst Name = \"app\"\n")}, __rwMockArgs{})
+		args.Repo = __newRepoMock(nil)
+		args.Backup = __newBackupSrvMock(nil)
+	})
+
+	console.Init(func(options *console.OutArgs) {
+		options.Stdout = &__consoleWriter{}
+		options.Stderr = &__consoleWriter{}
+	})
+
+	res := b.Apply([]config.BumpFile{
+		{File: fsys.File("internal/build/version.go"), Key: "internal/build.Version", Required: &notRequired},
+	}, version.V("1.0.1"))
+
+	err := res.Err()
+
+	assert.ErrorIs(t, err, ErrBump, "Err()")
+	assert.ErrorIs(t, err, errGoIdentNotFound, "Err()")
+}
+
+func TestB_Apply_lockfiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		bmps      []config.BumpFile
+		exists    bool
+		changed   bool
+		wantFiles []fsys.File
+	}{
+		{
+			name:      "package.json with lock files",
+			bmps:      []config.BumpFile{{File: "web/package.json"}},
+			exists:    true,
+			changed:   true,
+			wantFiles: []fsys.File{"web/package.json", "web/package-lock.json", "web/npm-shrinkwrap.json"},
+		},
+		{
+			name:      "lock files do not exist",
+			bmps:      []config.BumpFile{{File: "composer.json"}},
+			changed:   true,
+			wantFiles: []fsys.File{"composer.json"},
+		},
+		{
+			name:      "package.json is not changed",
+			bmps:      []config.BumpFile{{File: "package.json"}},
+			exists:    true,
+			wantFiles: []fsys.File{"package.json"},
+		},
+		{
+			name:      "lock file in bump files",
+			bmps:      []config.BumpFile{{File: "composer.json"}, {File: "composer.lock"}},
+			exists:    true,
+			changed:   true,
+			wantFiles: []fsys.File{"composer.json", "composer.lock"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			process := __newProcessMock(__processMockArgs{data: []string{"{}"}, changed: tt.changed})
+
+			b := New(func(args *Args) {
+				args.RW = __newRWMock(__rwMockArgs{exists: tt.exists}, __rwMockArgs{})
+				args.Repo = __newRepoMock(nil)
+				args.Backup = __newBackupSrvMock(nil)
+				args.Proc = process
+			})
+
+			console.Init(func(options *console.OutArgs) {
+				options.Stdout = &__consoleWriter{}
+				options.Stderr = &__consoleWriter{}
+			})
+
+			res := b.Apply(tt.bmps, version.V("1.0.0"))
+
+			files := make([]fsys.File, 0, len(res))
+			for _, r := range res {
+				files = append(files, r.File)
+			}
+
+			assert.Equal(t, tt.wantFiles, files)
+
+			for _, r := range res[len(tt.bmps):] {
+				assert.False(t, r.Required, "lock file is not required")
+			}
+		})
+	}
+}
+
 func TestB_applyToFile(t *testing.T) {
 	const ver = version.V("1.0.0")
 	bmp := config.BumpFile{
@@ -312,7 +482,7 @@ func TestB_applyToFile(t *testing.T) {
 				args.Proc = tt.fields.process
 			})
 
-			got, err := b.applyToFile(bmp, ver)
+			got, err := b.applyToFile(bmp, version.V("0.9.0"), ver)
 
 			tt.wantErr(t, err, "B.applyToFile() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -342,6 +512,10 @@ func TestB_read(t *testing.T) {
 	type wantCall struct {
 		predefinedJSON bool
 		customFile     bool
+		toml           bool
+		yaml           bool
+		xml            bool
+		golang         bool
 	}
 
 	tests := []struct {
@@ -383,6 +557,122 @@ func TestB_read(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "call TOML",
+			fields: fields{
+				rw:      __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{}),
+			},
+			args: args{
+				bmp: config.BumpFile{
+					File: fsys.File("Cargo.toml"),
+				},
+			},
+			wantCall: wantCall{
+				toml: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "call custom file for TOML without keys",
+			fields: fields{
+				rw:      __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{}),
+			},
+			args: args{
+				bmp: config.BumpFile{
+					File:   fsys.File("config.toml"),
+					RegExp: []string{`^version = .+$`},
+				},
+			},
+			wantCall: wantCall{
+				customFile: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "call YAML",
+			fields: fields{
+				rw:      __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{}),
+			},
+			args: args{
+				bmp: config.BumpFile{
+					File: fsys.File("openapi.yml"),
+				},
+			},
+			wantCall: wantCall{
+				yaml: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "call custom file for YAML without keys",
+			fields: fields{
+				rw:      __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{}),
+			},
+			args: args{
+				bmp: config.BumpFile{
+					File: fsys.File("values.yaml"),
+				},
+			},
+			wantCall: wantCall{
+				customFile: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "call XML",
+			fields: fields{
+				rw:      __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{}),
+			},
+			args: args{
+				bmp: config.BumpFile{
+					File: fsys.File("app/App.csproj"),
+				},
+			},
+			wantCall: wantCall{
+				xml: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "call XML by type",
+			fields: fields{
+				rw:      __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{}),
+			},
+			args: args{
+				bmp: config.BumpFile{
+					File:  fsys.File("package.json"),
+					Type:  config.BumpTypeXML,
+					XPath: "/package/version",
+				},
+			},
+			wantCall: wantCall{
+				xml: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "call Go",
+			fields: fields{
+				rw:      __newRWMock(__rwMockArgs{}, __rwMockArgs{}),
+				process: __newProcessMock(__processMockArgs{}),
+			},
+			args: args{
+				bmp: config.BumpFile{
+					File: fsys.File("internal/build/version.go"),
+					Key:  "internal/build.Version",
+				},
+			},
+			wantCall: wantCall{
+				golang: true,
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "read error",
 			fields: fields{
@@ -420,20 +710,44 @@ func TestB_read(t *testing.T) {
 				args.Proc = tt.fields.process
 			})
 
-			_, _, err := b.read(tt.args.bmp, version.V("1.0.0"))
+			_, _, _, err := b.read(tt.args.bmp, version.V("0.9.0"), version.V("1.0.0"))
 
 			tt.wantErr(t, err, "B.read() error = %v, wantErr %v", err, tt.wantErr)
 
 			if tt.wantCall.predefinedJSON {
-				tt.fields.process.AssertCalled(t, "PredefinedJSON", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+				tt.fields.process.AssertCalled(t, "PredefinedJSON", mock.Anything, tt.args.bmp, version.V("0.9.0"), version.V("1.0.0"))
 			} else {
-				tt.fields.process.AssertNotCalled(t, "PredefinedJSON", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+				tt.fields.process.AssertNotCalled(t, "PredefinedJSON", mock.Anything, tt.args.bmp, version.V("0.9.0"), version.V("1.0.0"))
 			}
 
 			if tt.wantCall.customFile {
-				tt.fields.process.AssertCalled(t, "CustomFile", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+				tt.fields.process.AssertCalled(t, "CustomFile", mock.Anything, tt.args.bmp, version.V("0.9.0"), version.V("1.0.0"))
+			} else {
+				tt.fields.process.AssertNotCalled(t, "CustomFile", mock.Anything, tt.args.bmp, version.V("0.9.0"), version.V("1.0.0"))
+			}
+
+			if tt.wantCall.toml {
+				tt.fields.process.AssertCalled(t, "TOML", mock.Anything, tt.args.bmp, version.V("1.0.0"))
 			} else {
-				tt.fields.process.AssertNotCalled(t, "CustomFile", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+				tt.fields.process.AssertNotCalled(t, "TOML", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+			}
+
+			if tt.wantCall.yaml {
+				tt.fields.process.AssertCalled(t, "YAML", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+			} else {
+				tt.fields.process.AssertNotCalled(t, "YAML", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+			}
+
+			if tt.wantCall.golang {
+				tt.fields.process.AssertCalled(t, "Go", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+			} else {
+				tt.fields.process.AssertNotCalled(t, "Go", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+			}
+
+			if tt.wantCall.xml {
+				tt.fields.process.AssertCalled(t, "XML", mock.Anything, tt.args.bmp, version.V("1.0.0"))
+			} else {
+				tt.fields.process.AssertNotCalled(t, "XML", mock.Anything, tt.args.bmp, version.V("1.0.0"))
 			}
 
 		})
@@ -485,7 +799,7 @@ func TestB_write(t *testing.T) {
 				args.RW = tt.fields.rw
 			})
 
-			err := b.write("test", []string{"foo", "bar"})
+			err := b.write("test", []byte("foo\nbar\n"))
 
 			tt.wantErr(t, err, "B.write() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -506,9 +820,15 @@ func (m *__repoMock) Add(files ...fsys.File) error {
 	return ret.Error(0)
 }
 
+func (m *__repoMock) Current() (version.V, error) {
+	ret := m.Called()
+	return ret.Get(0).(version.V), ret.Error(1)
+}
+
 func __newRepoMock(err error) *__repoMock {
 	m := &__repoMock{}
 	m.On("Add", mock.Anything).Return(err)
+	m.On("Current").Return(version.V("0.9.0"), nil)
 
 	return m
 }
@@ -534,13 +854,43 @@ type __processMock struct {
 }
 
 // PredefinedJSON
-func (m *__processMock) PredefinedJSON(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error) {
-	ret := m.Called(r, bmp, v)
+func (m *__processMock) PredefinedJSON(r io.Reader, bmp config.BumpFile, current, v version.V) ([]string, bool, error) {
+	ret := m.Called(r, bmp, current, v)
 	return ret.Get(0).([]string), ret.Bool(1), ret.Error(2)
 }
 
 // CustomFile
-func (m *__processMock) CustomFile(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error) {
+func (m *__processMock) CustomFile(r io.Reader, bmp config.BumpFile, current, v version.V) ([]string, bool, error) {
+	ret := m.Called(r, bmp, current, v)
+	return ret.Get(0).([]string), ret.Bool(1), ret.Error(2)
+}
+
+// TOML
+func (m *__processMock) TOML(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error) {
+	ret := m.Called(r, bmp, v)
+	return ret.Get(0).([]string), ret.Bool(1), ret.Error(2)
+}
+
+// YAML
+func (m *__processMock) YAML(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error) {
+	ret := m.Called(r, bmp, v)
+	return ret.Get(0).([]string), ret.Bool(1), ret.Error(2)
+}
+
+// XML
+func (m *__processMock) XML(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error) {
+	ret := m.Called(r, bmp, v)
+	return ret.Get(0).([]string), ret.Bool(1), ret.Error(2)
+}
+
+// Go
+func (m *__processMock) Go(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error) {
+	ret := m.Called(r, bmp, v)
+	return ret.Get(0).([]string), ret.Bool(1), ret.Error(2)
+}
+
+// Lockfile
+func (m *__processMock) Lockfile(r io.Reader, bmp config.BumpFile, v version.V) ([]string, bool, error) {
 	ret := m.Called(r, bmp, v)
 	return ret.Get(0).([]string), ret.Bool(1), ret.Error(2)
 }
@@ -553,8 +903,61 @@ type __processMockArgs struct {
 
 func __newProcessMock(a __processMockArgs) *__processMock {
 	m := &__processMock{}
-	m.On("PredefinedJSON", mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
-	m.On("CustomFile", mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
+	m.On("PredefinedJSON", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
+	m.On("CustomFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
+	m.On("TOML", mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
+	m.On("YAML", mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
+	m.On("XML", mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
+	m.On("Go", mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
+	m.On("Lockfile", mock.Anything, mock.Anything, mock.Anything).Return(a.data, a.changed, a.err)
 
 	return m
 }
+
+func TestResults_Err(t *testing.T) {
+	tests := []struct {
+		name      string
+		rs        Results
+		assertion assert.ErrorAssertionFunc
+		wantErr   error
+	}{
+		{
+			name: "ok",
+			rs: Results{
+				{File: "a", Changed: true, Required: true},
+				{File: "b", Changed: false, Required: false},
+				{File: "c", Err: fmt.Errorf("open file c error: %w", fs.ErrNotExist), Required: false},
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name:      "required not changed",
+			rs:        Results{{File: "a", Required: true}},
+			assertion: assert.Error,
+			wantErr:   ErrRequired,
+		},
+		{
+			name:      "required error",
+			rs:        Results{{File: "a", Changed: true, Err: assert.AnError, Required: true}},
+			assertion: assert.Error,
+			wantErr:   ErrRequired,
+		},
+		{
+			name:      "not required error",
+			rs:        Results{{File: "a", Err: assert.AnError, Required: false}},
+			assertion: assert.Error,
+			wantErr:   ErrBump,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rs.Err()
+			tt.assertion(t, err, "Err()")
+
+			if err != nil {
+				assert.ErrorIs(t, err, tt.wantErr, "Err()")
+			}
+		})
+	}
+}
@@ -1,33 +1,51 @@
 package bump
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 
 	"github.com/klimby/version/internal/config"
 	"github.com/klimby/version/internal/service/console"
 	"github.com/klimby/version/pkg/version"
 )
 
+// versionRegex matches SemVer version with optional "v" prefix, pre-release and build metadata.
+var versionRegex = regexp.MustCompile(`\b(v?)((?:0|[1-9]\d*)\.(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)` +
+	`(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?)\b`)
+
 // process is a content processor.
 type process struct{}
 
 // PredefinedJSON process predefined JSON file.
-func (process) PredefinedJSON(r io.Reader, bmp config.BumpFile, v version.V) (_ []string, changed bool, err error) {
-	scanner := bufio.NewScanner(r)
-	versionRegex := regexp.MustCompile(`"version"\s*:\s*".*?"`)
+// Replaces version in the first "version" field, if it is the current version.
+func (process) PredefinedJSON(r io.Reader, bmp config.BumpFile, current, v version.V) (_ []string, changed bool, err error) {
+	scanner := newScanner(r)
+	fieldRegex := regexp.MustCompile(`("version"\s*:\s*")(.*?)(")`)
+	rpl := newReplacer(bmp, current, v)
 	var content []string
 
-	replacer := `"version": "` + v.FormatString() + `"`
+	found := false
 
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		if !changed && versionRegex.MatchString(line) {
-			line = versionRegex.ReplaceAllString(line, replacer)
-			changed = true
+		if !found {
+			if m := fieldRegex.FindStringSubmatchIndex(line); m != nil {
+				found = true
+
+				value, ok := rpl.replace(line[m[4]:m[5]])
+				if !ok && rpl.current == "" {
+					// not SemVer value is replaced, if the current version is unknown.
+					value, ok = rpl.next, true
+				}
+
+				if ok {
+					line = line[:m[4]] + value + line[m[5]:]
+					changed = true
+				}
+			}
 		}
 
 		content = append(content, line)
@@ -41,46 +59,165 @@ func (process) PredefinedJSON(r io.Reader, bmp config.BumpFile, v version.V) (_
 }
 
 // CustomFile process custom file.
-func (process) CustomFile(r io.Reader, bmp config.BumpFile, v version.V) (_ []string, changed bool, err error) {
-	scanner := bufio.NewScanner(r)
-	versionRegex := regexp.MustCompile(`\d+\.\d+\.\d+`)
+// Replaces the current version in lines from start to end, that match one of regexps.
+// If regexp has a named group "version", then only the group is replaced.
+// In multi-line mode regexps are applied to the text from start to end lines.
+func (process) CustomFile(r io.Reader, bmp config.BumpFile, current, v version.V) (_ []string, changed bool, err error) {
+	scanner := newScanner(r)
+	rpl := newReplacer(bmp, current, v)
 	var content []string
 
+	for scanner.Scan() {
+		content = append(content, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, false, fmt.Errorf("scan file %s error: %w", bmp.File.String(), err)
+	}
+
 	start, end, regArr := handleBumpFile(bmp)
 
-	lineNum := 0
+	if bmp.Multiline {
+		content, changed = rpl.multiline(content, start, end, regArr)
 
-	for scanner.Scan() {
-		line := scanner.Text()
+		return content, changed, nil
+	}
 
-		if lineNum >= start && lineNum <= end {
-			if versionRegex.MatchString(line) {
-				if len(regArr) > 0 {
-					for i := range regArr {
-						if regArr[i].MatchString(line) {
-							line = versionRegex.ReplaceAllString(line, v.FormatString())
-							changed = true
-
-							break
-						}
-					}
-				} else {
-					line = versionRegex.ReplaceAllString(line, v.FormatString())
-					changed = true
-				}
+	for i := start; i <= end && i < len(content); i++ {
+		if l, ok := rpl.line(content[i], regArr); ok {
+			content[i] = l
+			changed = true
+		}
+	}
+
+	return content, changed, nil
+}
+
+// replacer replaces versions in strings.
+type replacer struct {
+	// current is a version for replace. If empty, any version is replaced.
+	current version.V
+	next    string
+}
+
+// newReplacer creates replacer for bump file.
+// Current version is ignored, if it is empty, start version (no tags) or bump file allows any version.
+func newReplacer(bmp config.BumpFile, current, v version.V) replacer {
+	if bmp.AnyVersion || current.Empty() || current.Equal(current.Start()) {
+		current = ""
+	}
+
+	return replacer{
+		current: current,
+		next:    v.FormatString(),
+	}
+}
+
+// line replaces version in line.
+// Regexps with version group replace the group, other regexps only select the line.
+func (r replacer) line(line string, regs []regexp.Regexp) (string, bool) {
+	if len(regs) == 0 {
+		return r.replace(line)
+	}
+
+	for i := range regs {
+		if regs[i].SubexpIndex(config.VersionGroup) >= 0 {
+			if l, ok := r.replaceGroup(line, &regs[i]); ok {
+				return l, true
 			}
+
+			continue
 		}
 
-		content = append(content, line)
+		if regs[i].MatchString(line) {
+			return r.replace(line)
+		}
+	}
+
+	return line, false
+}
 
-		lineNum++
+// multiline replaces version groups of regexps in text from start to end lines.
+func (r replacer) multiline(content []string, start, end int, regs []regexp.Regexp) ([]string, bool) {
+	if start >= len(content) {
+		return content, false
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, changed, fmt.Errorf("scan file %s error: %w", bmp.File.String(), err)
+	end = min(end, len(content)-1)
+	text := strings.Join(content[start:end+1], "\n")
+	changed := false
+
+	for i := range regs {
+		if t, ok := r.replaceGroup(text, &regs[i]); ok {
+			text = t
+			changed = true
+		}
 	}
 
-	return content, changed, nil
+	if !changed {
+		return content, false
+	}
+
+	result := make([]string, 0, len(content))
+	result = append(result, content[:start]...)
+	result = append(result, strings.Split(text, "\n")...)
+
+	return append(result, content[end+1:]...), true
+}
+
+// replaceGroup replaces version group of all rgx matches in s.
+// The "v" prefix of the group value is kept. Returns false, if s has no matches.
+func (r replacer) replaceGroup(s string, rgx *regexp.Regexp) (string, bool) {
+	idx := rgx.SubexpIndex(config.VersionGroup)
+	if idx < 0 {
+		return s, false
+	}
+
+	var b strings.Builder
+
+	last, changed := 0, false
+
+	for _, m := range rgx.FindAllStringSubmatchIndex(s, -1) {
+		start, end := m[2*idx], m[2*idx+1]
+		if start < 0 {
+			continue
+		}
+
+		b.WriteString(s[last:start])
+
+		if strings.HasPrefix(s[start:end], "v") {
+			b.WriteString("v")
+		}
+
+		b.WriteString(r.next)
+
+		last = end
+		changed = true
+	}
+
+	b.WriteString(s[last:])
+
+	return b.String(), changed
+}
+
+// replace replaces versions in s. The "v" prefix of the version is kept.
+// Returns false, if s has no version for replace.
+func (r replacer) replace(s string) (string, bool) {
+	changed := false
+
+	s = versionRegex.ReplaceAllStringFunc(s, func(found string) string {
+		m := versionRegex.FindStringSubmatch(found)
+
+		if r.current != "" && !r.current.Equal(version.V(m[2])) {
+			return found
+		}
+
+		changed = true
+
+		return m[1] + r.next
+	})
+
+	return s, changed
 }
 
 // handleBumpFile handles BumpFile.
@@ -14,9 +14,10 @@ import (
 
 func Test_process_PredefinedJSON(t *testing.T) {
 	type args struct {
-		r   io.Reader
-		bmp config.BumpFile
-		v   version.V
+		r       io.Reader
+		bmp     config.BumpFile
+		current version.V
+		v       version.V
 	}
 
 	ioReader := func(data string, readError error) io.Reader {
@@ -50,6 +51,51 @@ func Test_process_PredefinedJSON(t *testing.T) {
 			wantChanged: assert.True,
 			wantErr:     assert.NoError,
 		},
+		{
+			name: "current version with prefix",
+			args: args{
+				r: ioReader(`{"version" : "v1.0.0-rc.1+sha.abc"}`, nil),
+				bmp: config.BumpFile{
+					File: fsys.File("package.json"),
+				},
+				current: version.V("1.0.0-rc.1"),
+				v:       version.V("1.0.0"),
+			},
+
+			want:        []string{`{"version" : "v1.0.0"}`},
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "not current version",
+			args: args{
+				r: ioReader("{\n\"version\": \"2.0.0\",\n\"dependencies\": {\"version\": \"1.0.0\"}\n}", nil),
+				bmp: config.BumpFile{
+					File: fsys.File("package.json"),
+				},
+				current: version.V("1.0.0"),
+				v:       version.V("1.0.1"),
+			},
+
+			want:        []string{"{", `"version": "2.0.0",`, `"dependencies": {"version": "1.0.0"}`, "}"},
+			wantChanged: assert.False,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "not semver value without tags",
+			args: args{
+				r: ioReader(`{"version": ""}`, nil),
+				bmp: config.BumpFile{
+					File: fsys.File("package.json"),
+				},
+				current: version.V("0.0.0"),
+				v:       version.V("1.0.1"),
+			},
+
+			want:        []string{`{"version": "1.0.1"}`},
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
 		{
 			name: "read error",
 			args: args{
@@ -67,7 +113,7 @@ func Test_process_PredefinedJSON(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pr := process{}
-			got, gotChanged, err := pr.PredefinedJSON(tt.args.r, tt.args.bmp, tt.args.v)
+			got, gotChanged, err := pr.PredefinedJSON(tt.args.r, tt.args.bmp, tt.args.current, tt.args.v)
 			if !tt.wantErr(t, err, fmt.Sprintf("PredefinedJSON(%v, %v, %v)", tt.args.r, tt.args.bmp, tt.args.v)) {
 				return
 			}
@@ -80,9 +126,10 @@ func Test_process_PredefinedJSON(t *testing.T) {
 
 func Test_process_CustomFile(t *testing.T) {
 	type args struct {
-		r   io.Reader
-		bmp config.BumpFile
-		v   version.V
+		r       io.Reader
+		bmp     config.BumpFile
+		current version.V
+		v       version.V
 	}
 
 	ioReader := func(data string, readError error) io.Reader {
@@ -131,6 +178,106 @@ func Test_process_CustomFile(t *testing.T) {
 			wantChanged: assert.True,
 			wantErr:     assert.NoError,
 		},
+		{
+			name: "only current version",
+			args: args{
+				r: ioReader("app 1.2.3-beta.1 uses lib 1.2.3 and 1.0.0", nil),
+				bmp: config.BumpFile{
+					File: fsys.File("file"),
+				},
+				current: version.V("1.2.3-beta.1"),
+				v:       version.V("1.2.3-beta.2"),
+			},
+
+			want:        []string{"app 1.2.3-beta.2 uses lib 1.2.3 and 1.0.0"},
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "any version",
+			args: args{
+				r: ioReader("app v1.2.3-beta.1+build.5 uses lib 1.0.0", nil),
+				bmp: config.BumpFile{
+					File:       fsys.File("file"),
+					AnyVersion: true,
+				},
+				current: version.V("1.2.3-beta.1"),
+				v:       version.V("1.3.0"),
+			},
+
+			want:        []string{"app v1.3.0 uses lib 1.3.0"},
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "current version not found",
+			args: args{
+				r: ioReader("1.0.0", nil),
+				bmp: config.BumpFile{
+					File: fsys.File("file"),
+				},
+				current: version.V("2.0.0"),
+				v:       version.V("2.0.1"),
+			},
+
+			want:        []string{"1.0.0"},
+			wantChanged: assert.False,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "named group",
+			args: args{
+				r: ioReader("package build\n\nconst Version = \"v1.0.0-dev\" // 2.0.0\nLABEL version=1.0.0", nil),
+				bmp: config.BumpFile{
+					File: fsys.File("file"),
+					RegExp: []string{
+						`const Version = "(?P<version>[^"]+)"`,
+						`^LABEL version=(?P<version>\S+)`,
+					},
+				},
+				current: version.V("0.9.0"),
+				v:       version.V("1.0.1"),
+			},
+
+			want:        []string{"package build", "", `const Version = "v1.0.1" // 2.0.0`, "LABEL version=1.0.1"},
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "multiline",
+			args: args{
+				r: ioReader("<version>\n  1.0.0\n</version>\n<other>\n  1.0.0\n</other>", nil),
+				bmp: config.BumpFile{
+					File:      fsys.File("file"),
+					RegExp:    []string{`<version>\s*(?P<version>\S+)\s*</version>`},
+					Multiline: true,
+					End:       10,
+				},
+				v: version.V("1.0.1"),
+			},
+
+			want:        []string{"<version>", "  1.0.1", "</version>", "<other>", "  1.0.0", "</other>"},
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "multiline out of range",
+			args: args{
+				r: ioReader("<version>\n  1.0.0\n</version>", nil),
+				bmp: config.BumpFile{
+					File:      fsys.File("file"),
+					RegExp:    []string{`<version>\s*(?P<version>\S+)\s*</version>`},
+					Multiline: true,
+					Start:     1,
+					End:       2,
+				},
+				v: version.V("1.0.1"),
+			},
+
+			want:        []string{"<version>", "  1.0.0", "</version>"},
+			wantChanged: assert.False,
+			wantErr:     assert.NoError,
+		},
 		{
 			name: "read error",
 			args: args{
@@ -149,7 +296,7 @@ func Test_process_CustomFile(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pr := process{}
-			got, gotChanged, err := pr.CustomFile(tt.args.r, tt.args.bmp, tt.args.v)
+			got, gotChanged, err := pr.CustomFile(tt.args.r, tt.args.bmp, tt.args.current, tt.args.v)
 			if !tt.wantErr(t, err, fmt.Sprintf("CustomFile(%v, %v, %v)", tt.args.r, tt.args.bmp, tt.args.v)) {
 				return
 			}
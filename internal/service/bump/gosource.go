@@ -0,0 +1,131 @@
+package bump
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/pkg/version"
+)
+
+var (
+	// errGoIdentNotFound is returned, if the Go identifier is not found.
+	errGoIdentNotFound = errors.New("identifier not found")
+	// errGoIdentNotString is returned, if the Go identifier value is not a string literal.
+	errGoIdentNotString = errors.New("identifier value is not a string literal")
+)
+
+// Go process Go source file.
+// Replaces string literal of the constant or variable by package-qualified identifier (internal/build.Version).
+// Only the literal is changed, the rest of the file is kept byte-identical.
+// Returns changed false, if the literal already contains the version.
+func (process) Go(r io.Reader, bmp config.BumpFile, v version.V) (_ []string, changed bool, err error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false, fmt.Errorf("read file %s error: %w", bmp.File.String(), err)
+	}
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, bmp.File.String(), src, parser.ParseComments)
+	if err != nil {
+		return nil, false, fmt.Errorf("parse file %s error: %w", bmp.File.String(), err)
+	}
+
+	pkg, name := bmp.GoIdent()
+
+	if !goPackageMatch(bmp.File.String(), file.Name.Name, pkg) {
+		return nil, false, fmt.Errorf("%w: %s is not in package of file %s", errGoIdentNotFound, bmp.Key, bmp.File.String())
+	}
+
+	lit, err := goStringLit(file, name)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %s in file %s", err, bmp.Key, bmp.File.String())
+	}
+
+	value := v.FormatString()
+
+	if old, err := strconv.Unquote(lit.Value); err == nil && strings.HasPrefix(old, "v") {
+		value = "v" + value
+	}
+
+	newLit := strconv.Quote(value)
+	if strings.HasPrefix(lit.Value, "`") {
+		newLit = "`" + value + "`"
+	}
+
+	start := fset.Position(lit.Pos()).Offset
+	end := start + len(lit.Value)
+
+	var b bytes.Buffer
+
+	b.Write(src[:start])
+	b.WriteString(newLit)
+	b.Write(src[end:])
+
+	return splitLines(b.Bytes()), newLit != lit.Value, nil
+}
+
+// goPackageMatch returns true if package path is empty or matches package name and directory of the file.
+func goPackageMatch(file, name, pkg string) bool {
+	if pkg == "" {
+		return true
+	}
+
+	if path.Base(pkg) != name && path.Base(pkg) != path.Base(filepath.ToSlash(filepath.Dir(file))) {
+		return false
+	}
+
+	if !strings.Contains(pkg, "/") {
+		return true
+	}
+
+	dir := "/" + filepath.ToSlash(filepath.Dir(file))
+
+	return strings.HasSuffix(dir, "/"+strings.Trim(pkg, "/"))
+}
+
+// goStringLit returns string literal of the top-level constant or variable.
+func goStringLit(file *ast.File, name string) (*ast.BasicLit, error) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+
+			for i, n := range vs.Names {
+				if n.Name != name {
+					continue
+				}
+
+				if i >= len(vs.Values) {
+					return nil, errGoIdentNotString
+				}
+
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return nil, errGoIdentNotString
+				}
+
+				return lit, nil
+			}
+		}
+	}
+
+	return nil, errGoIdentNotFound
+}
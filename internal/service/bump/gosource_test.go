@@ -0,0 +1,106 @@
+package bump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/pkg/version"
+	"github.com/stretchr/testify/assert"
+)
+
+const __versionGo = `// Package build provides build information.
+package build
+
+// Version is an application version.
+const Version = "v1.0.0" // 1.0.0
+
+const (
+	// Name is an application name.
+	Name = "app"
+	Number   = 1 // not gofmt formatted
+)
+
+var Raw = ` + "`1.0.0`" + `
+`
+
+func Test_process_Go(t *testing.T) {
+	tests := []struct {
+		name        string
+		bmp         config.BumpFile
+		v           version.V
+		want        string
+		wantChanged bool
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "qualified constant",
+			bmp:         config.BumpFile{File: fsys.File("internal/build/version.go"), Key: "internal/build.Version"},
+			v:           version.V("1.0.1"),
+			want:        strings.Replace(__versionGo, `"v1.0.0"`, `"v1.0.1"`, 1),
+			wantChanged: true,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "raw string variable",
+			bmp:         config.BumpFile{File: fsys.File("internal/build/version.go"), Key: "build.Raw"},
+			v:           version.V("1.0.1"),
+			want:        strings.Replace(__versionGo, "`1.0.0`", "`1.0.1`", 1),
+			wantChanged: true,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "same version",
+			bmp:         config.BumpFile{File: fsys.File("internal/build/version.go"), Key: "Version"},
+			v:           version.V("1.0.0"),
+			want:        __versionGo,
+			wantChanged: false,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:    "not found",
+			bmp:     config.BumpFile{File: fsys.File("internal/build/version.go"), Key: "build.Missing"},
+			v:       version.V("1.0.1"),
+			wantErr: assert.Error,
+		},
+		{
+			name:    "not string",
+			bmp:     config.BumpFile{File: fsys.File("internal/build/version.go"), Key: "Number"},
+			v:       version.V("1.0.1"),
+			wantErr: assert.Error,
+		},
+		{
+			name:    "other package",
+			bmp:     config.BumpFile{File: fsys.File("internal/build/version.go"), Key: "internal/app.Version"},
+			v:       version.V("1.0.1"),
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := process{}
+			r := &__RWC{}
+			_, _ = r.Write([]byte(__versionGo))
+
+			got, changed, err := pr.Go(r, tt.bmp, tt.v)
+			if !tt.wantErr(t, err, "Go()") || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.wantChanged, changed, "Go() changed")
+			assert.Equal(t, strings.Split(strings.TrimSuffix(tt.want, "\n"), "\n"), got, "Go()")
+		})
+	}
+}
+
+func Test_process_Go_parseError(t *testing.T) {
+	pr := process{}
+	r := &__RWC{}
+	_, _ = r.Write([]byte("package build\nconst Version = "))
+
+	_, _, err := pr.Go(r, config.BumpFile{File: fsys.File("version.go"), Key: "Version"}, version.V("1.0.1"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package bump
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// _maxLineSize is a maximum line size for files scanner.
+const _maxLineSize = 16 * 1024 * 1024
+
+// _bom is a UTF-8 byte order mark.
+var _bom = []byte{0xEF, 0xBB, 0xBF}
+
+// layout is a text layout of the file: BOM, line endings and final new line.
+type layout struct {
+	bom      bool
+	eol      string
+	finalEOL bool
+}
+
+// newLayout detects layout of data and returns data without BOM and with LF line endings.
+// Line endings are detected by the first line: CRLF or LF.
+func newLayout(data []byte) (layout, []byte) {
+	l := layout{eol: "\n", finalEOL: true}
+
+	if bytes.HasPrefix(data, _bom) {
+		l.bom = true
+		data = data[len(_bom):]
+	}
+
+	if i := bytes.IndexByte(data, '\n'); i > 0 && data[i-1] == '\r' {
+		l.eol = "\r\n"
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	}
+
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		l.finalEOL = false
+	}
+
+	return l, data
+}
+
+// bytes returns lines as file content with the layout.
+func (l layout) bytes(lines []string) []byte {
+	var b bytes.Buffer
+
+	if l.bom {
+		b.Write(_bom)
+	}
+
+	b.WriteString(strings.Join(lines, l.eol))
+
+	if l.finalEOL && len(lines) > 0 {
+		b.WriteString(l.eol)
+	}
+
+	return b.Bytes()
+}
+
+// splitLines splits content with LF line endings to lines.
+// The last empty line is a final new line, it is added on write.
+func splitLines(data []byte) []string {
+	content := strings.Split(string(data), "\n")
+
+	if len(content) > 0 && content[len(content)-1] == "" {
+		content = content[:len(content)-1]
+	}
+
+	return content
+}
+
+// newScanner returns lines scanner with buffer for long lines.
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), _maxLineSize)
+
+	return scanner
+}
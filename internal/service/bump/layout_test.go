@@ -0,0 +1,70 @@
+package bump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_layout(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     layout
+		wantData string
+	}{
+		{
+			name:     "lf",
+			data:     "a\nb\n",
+			want:     layout{eol: "\n", finalEOL: true},
+			wantData: "a\nb\n",
+		},
+		{
+			name:     "crlf without final new line",
+			data:     "a\r\nb",
+			want:     layout{eol: "\r\n"},
+			wantData: "a\nb",
+		},
+		{
+			name:     "bom crlf",
+			data:     "\xEF\xBB\xBFa\r\nb\r\n",
+			want:     layout{bom: true, eol: "\r\n", finalEOL: true},
+			wantData: "a\nb\n",
+		},
+		{
+			name:     "single line",
+			data:     "a",
+			want:     layout{eol: "\n"},
+			wantData: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, data := newLayout([]byte(tt.data))
+
+			assert.Equal(t, tt.want, got, "newLayout()")
+			assert.Equal(t, tt.wantData, string(data), "newLayout() data")
+
+			// restore original content
+			lines := strings.Split(strings.TrimSuffix(tt.wantData, "\n"), "\n")
+			assert.Equal(t, tt.data, string(got.bytes(lines)), "bytes()")
+		})
+	}
+}
+
+func Test_newScanner(t *testing.T) {
+	long := strings.Repeat("a", 1024*1024)
+
+	scanner := newScanner(strings.NewReader(long + "\n1.0.0"))
+
+	var lines []string
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	assert.NoError(t, scanner.Err(), "Scan()")
+	assert.Equal(t, []string{long, "1.0.0"}, lines, "Scan()")
+}
@@ -0,0 +1,159 @@
+package bump
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/pkg/version"
+)
+
+// _lockfilePaths are paths to the root package version in npm and composer lock files:
+// top-level "version" and packages[""].version (npm lockfile v2 and v3).
+var _lockfilePaths = [][]string{
+	{"version"},
+	{"packages", "", "version"},
+}
+
+// Lockfile process npm or composer lock file.
+// Replaces only the root package version fields, dependency entries and formatting are kept.
+func (process) Lockfile(r io.Reader, bmp config.BumpFile, v version.V) (_ []string, changed bool, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false, fmt.Errorf("read file %s error: %w", bmp.File.String(), err)
+	}
+
+	spans, err := jsonStringSpans(data, _lockfilePaths)
+	if err != nil {
+		return nil, false, fmt.Errorf("parse file %s error: %w", bmp.File.String(), err)
+	}
+
+	var b bytes.Buffer
+
+	last := 0
+	value := v.FormatString()
+
+	for _, s := range spans {
+		b.Write(data[last:s[0]])
+		b.WriteString(value)
+
+		if string(data[s[0]:s[1]]) != value {
+			changed = true
+		}
+
+		last = s[1]
+	}
+
+	b.Write(data[last:])
+
+	return splitLines(b.Bytes()), changed, nil
+}
+
+// jsonFrame is an opened JSON object or array.
+type jsonFrame struct {
+	// path is a path of the object or array.
+	path []string
+	// object is true for object, false for array.
+	object bool
+	// key is a key of the next value in object.
+	key string
+	// wantKey is true, if the next token in object is a key.
+	wantKey bool
+	// index is an index of the next value in array.
+	index int
+}
+
+// child returns path of the next value in the frame.
+func (f *jsonFrame) child() []string {
+	seg := f.key
+	if !f.object {
+		seg = strconv.Itoa(f.index)
+	}
+
+	return append(slices.Clone(f.path), seg)
+}
+
+// next moves the frame to the next value.
+func (f *jsonFrame) next() {
+	if f.object {
+		f.wantKey = true
+	} else {
+		f.index++
+	}
+}
+
+// jsonStringSpans returns byte positions [start, end) of string values by paths (without quotes).
+// Numeric path segment is an index in array. Not string values are skipped.
+func jsonStringSpans(data []byte, paths [][]string) ([][2]int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var stack []*jsonFrame
+	var spans [][2]int
+
+	for {
+		start := int(dec.InputOffset())
+
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				if len(stack) > 0 {
+					return nil, io.ErrUnexpectedEOF
+				}
+
+				return spans, nil
+			}
+
+			return nil, err
+		}
+
+		var top *jsonFrame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		var path []string
+		if top != nil {
+			path = top.child()
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				stack = append(stack, &jsonFrame{path: path, object: t == '{', wantKey: t == '{'})
+			default:
+				stack = stack[:len(stack)-1]
+
+				if len(stack) > 0 {
+					stack[len(stack)-1].next()
+				}
+			}
+		case string:
+			if top != nil && top.object && top.wantKey {
+				top.key, top.wantKey = t, false
+
+				continue
+			}
+
+			if slices.ContainsFunc(paths, func(p []string) bool { return slices.Equal(p, path) }) {
+				end := int(dec.InputOffset())
+				s := start + bytes.IndexByte(data[start:end], '"')
+				spans = append(spans, [2]int{s + 1, end - 1})
+			}
+
+			if top != nil {
+				top.next()
+			}
+		default:
+			if top != nil {
+				top.next()
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package bump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/pkg/version"
+	"github.com/stretchr/testify/assert"
+)
+
+const __packageLock = `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {
+    "": {
+      "name": "app",
+      "version": "1.0.0",
+      "dependencies": {
+        "lib": "^1.0.0"
+      }
+    },
+    "node_modules/lib": {
+      "version": "1.0.0",
+      "resolved": "https://registry.npmjs.org/lib/-/lib-1.0.0.tgz"
+    }
+  },
+  "dependencies": {
+    "lib": {
+      "version": "1.0.0"
+    }
+  }
+}
+`
+
+const __composerLock = `{
+    "content-hash": "abc",
+    "packages": [
+        {
+            "name": "vendor/lib",
+            "version": "1.0.0"
+        }
+    ],
+    "plugin-api-version": "2.6.0"
+}
+`
+
+func Test_process_Lockfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		bmp         config.BumpFile
+		want        string
+		wantChanged assert.BoolAssertionFunc
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name: "package-lock.json",
+			data: __packageLock,
+			bmp:  config.BumpFile{File: fsys.File("package-lock.json")},
+			want: strings.Replace(
+				strings.Replace(__packageLock, `"version": "1.0.0",
+  "lockfileVersion"`, `"version": "1.0.1",
+  "lockfileVersion"`, 1),
+				`"name": "app",
+      "version": "1.0.0"`, `"name": "app",
+      "version": "1.0.1"`, 1),
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "composer.lock without root version",
+			data:        __composerLock,
+			bmp:         config.BumpFile{File: fsys.File("composer.lock")},
+			want:        __composerLock,
+			wantChanged: assert.False,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "already bumped",
+			data:        `{"version": "1.0.1"}`,
+			bmp:         config.BumpFile{File: fsys.File("package-lock.json")},
+			want:        `{"version": "1.0.1"}`,
+			wantChanged: assert.False,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "parse error",
+			data:        `{"version": "1.0.0"`,
+			bmp:         config.BumpFile{File: fsys.File("package-lock.json")},
+			wantChanged: assert.False,
+			wantErr:     assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := process{}
+			r := &__RWC{}
+			_, _ = r.Write([]byte(tt.data))
+
+			got, gotChanged, err := pr.Lockfile(r, tt.bmp, version.V("1.0.1"))
+			if !tt.wantErr(t, err, "Lockfile()") {
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, strings.Split(strings.TrimSuffix(tt.want, "\n"), "\n"), got, "Lockfile()")
+			}
+
+			tt.wantChanged(t, gotChanged, "Lockfile()")
+		})
+	}
+}
+
+func Test_jsonStringSpans(t *testing.T) {
+	data := []byte(`{"a": [1, {"v": "x"}, "y"], "v" : "z", "n": {"v": 2}}`)
+
+	got, err := jsonStringSpans(data, [][]string{{"a", "1", "v"}, {"a", "2"}, {"v"}, {"n", "v"}})
+	assert.NoError(t, err)
+
+	values := make([]string, 0, len(got))
+	for _, s := range got {
+		values = append(values, string(data[s[0]:s[1]]))
+	}
+
+	assert.Equal(t, []string{"x", "y", "z"}, values)
+}
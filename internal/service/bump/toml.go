@@ -0,0 +1,241 @@
+package bump
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/pkg/version"
+)
+
+// TOML process TOML file.
+// Replaces string values of the bump file keys, keeps comments and formatting.
+// Keys in array tables ([[table]]) and inline tables are not supported.
+func (process) TOML(r io.Reader, bmp config.BumpFile, v version.V) (_ []string, changed bool, err error) {
+	scanner := newScanner(r)
+	keys := bmp.Paths()
+	var content []string
+
+	// current table name, array table flag, open multi-line string delimiter and open brackets depth.
+	table, arrayTable, multiline, depth := "", false, "", 0
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case multiline != "":
+			if strings.Contains(line, multiline) {
+				multiline = ""
+			}
+		case depth > 0:
+			depth += tomlDepth(line)
+		default:
+			trimmed := strings.TrimSpace(line)
+
+			switch {
+			case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			case strings.HasPrefix(trimmed, "[["):
+				table, arrayTable = tomlHeader(trimmed[2:], "]]"), true
+			case strings.HasPrefix(trimmed, "["):
+				table, arrayTable = tomlHeader(trimmed[1:], "]"), false
+			default:
+				k, pos, ok := tomlKeyValue(line)
+				if !ok {
+					break
+				}
+
+				value := line[pos:]
+
+				if d := tomlMultiline(value); d != "" {
+					multiline = d
+
+					break
+				}
+
+				depth = tomlDepth(value)
+
+				if table != "" {
+					k = table + "." + k
+				}
+
+				if arrayTable || !slices.Contains(keys, k) {
+					break
+				}
+
+				if l, ok := tomlReplace(line, pos, v.FormatString()); ok {
+					line = l
+					changed = true
+				}
+			}
+		}
+
+		content = append(content, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, changed, fmt.Errorf("scan file %s error: %w", bmp.File.String(), err)
+	}
+
+	return content, changed, nil
+}
+
+// tomlHeader returns normalized table name from header without opening brackets.
+func tomlHeader(s, closing string) string {
+	end := tomlIndex(s, closing)
+	if end < 0 {
+		return ""
+	}
+
+	return tomlKey(s[:end])
+}
+
+// tomlKeyValue returns normalized dotted key and value position in line.
+func tomlKeyValue(line string) (k string, pos int, ok bool) {
+	eq := tomlIndex(line, "=")
+	if eq < 0 {
+		return "", 0, false
+	}
+
+	k = tomlKey(line[:eq])
+	if k == "" {
+		return "", 0, false
+	}
+
+	pos = eq + 1
+	for pos < len(line) && (line[pos] == ' ' || line[pos] == '\t') {
+		pos++
+	}
+
+	return k, pos, true
+}
+
+// tomlKey normalizes dotted key: removes spaces around dots and quotes.
+func tomlKey(s string) string {
+	var parts []string
+	var part strings.Builder
+	quote := byte(0)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				part.WriteByte(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			parts = append(parts, strings.TrimSpace(part.String()))
+			part.Reset()
+		default:
+			part.WriteByte(c)
+		}
+	}
+
+	parts = append(parts, strings.TrimSpace(part.String()))
+
+	for _, p := range parts {
+		if p == "" {
+			return ""
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// tomlIndex returns index of sub outside quoted strings or -1.
+func tomlIndex(s, sub string) int {
+	quote := byte(0)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return -1
+		case strings.HasPrefix(s[i:], sub):
+			return i
+		}
+	}
+
+	return -1
+}
+
+// tomlMultiline returns delimiter of multi-line string, if the value opens it and does not close.
+func tomlMultiline(value string) string {
+	for _, d := range []string{`"""`, `'''`} {
+		if strings.HasPrefix(value, d) && !strings.Contains(value[len(d):], d) {
+			return d
+		}
+	}
+
+	return ""
+}
+
+// tomlDepth returns count of opened and not closed brackets (arrays and inline tables) in value.
+func tomlDepth(value string) int {
+	depth := 0
+	quote := byte(0)
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return depth
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+
+	return depth
+}
+
+// tomlReplace replaces string value at pos in line with s.
+// Returns false, if value is not a single-line string.
+func tomlReplace(line string, pos int, s string) (string, bool) {
+	if pos >= len(line) {
+		return line, false
+	}
+
+	quote := line[pos]
+	if quote != '"' && quote != '\'' {
+		return line, false
+	}
+
+	for i := pos + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return line[:pos+1] + s + line[i:], true
+		}
+	}
+
+	return line, false
+}
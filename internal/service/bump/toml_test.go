@@ -0,0 +1,142 @@
+package bump
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/pkg/version"
+	"github.com/stretchr/testify/assert"
+)
+
+const __cargoToml = `# Cargo manifest
+[package]
+name = "app"
+version = "1.0.0" # current version
+description = """
+[dependencies]
+version = "0.0.1"
+"""
+
+[dependencies]
+serde = { version = "1.0.0", features = ["derive"] }
+rand = "0.8.5"
+
+[[bin]]
+name = "app"
+version = "1.0.0"`
+
+const __pyprojectToml = `[tool.poetry]
+name = "app"
+version = '1.0.0'
+authors = [
+    "[me]",
+]
+
+[tool.poetry.dependencies]
+python = "^3.10"`
+
+func Test_process_TOML(t *testing.T) {
+	type args struct {
+		data string
+		bmp  config.BumpFile
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		want        string
+		wantChanged assert.BoolAssertionFunc
+	}{
+		{
+			name: "cargo default key",
+			args: args{
+				data: __cargoToml,
+				bmp:  config.BumpFile{File: fsys.File("Cargo.toml")},
+			},
+			want: strings.Replace(__cargoToml,
+				`version = "1.0.0" # current version`, `version = "1.0.1" # current version`, 1),
+			wantChanged: assert.True,
+		},
+		{
+			name: "pyproject poetry fallback",
+			args: args{
+				data: __pyprojectToml,
+				bmp:  config.BumpFile{File: fsys.File("pyproject.toml")},
+			},
+			want:        strings.Replace(__pyprojectToml, `version = '1.0.0'`, `version = '1.0.1'`, 1),
+			wantChanged: assert.True,
+		},
+		{
+			name: "custom key",
+			args: args{
+				data: "[app]\nversion = \"1.0.0\"\n\n[tool]\napp . \"version\" = \"1.0.0\"",
+				bmp:  config.BumpFile{File: fsys.File("config.toml"), Key: "tool.app.version"},
+			},
+			want:        "[app]\nversion = \"1.0.0\"\n\n[tool]\napp . \"version\" = \"1.0.1\"",
+			wantChanged: assert.True,
+		},
+		{
+			name: "key not found",
+			args: args{
+				data: __pyprojectToml,
+				bmp:  config.BumpFile{File: fsys.File("pyproject.toml"), Key: "project.version"},
+			},
+			want:        __pyprojectToml,
+			wantChanged: assert.False,
+		},
+		{
+			name: "not string value",
+			args: args{
+				data: "[package]\nversion.workspace = true",
+				bmp:  config.BumpFile{File: fsys.File("Cargo.toml"), Key: "package.version.workspace"},
+			},
+			want:        "[package]\nversion.workspace = true",
+			wantChanged: assert.False,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := process{}
+			r := &__RWC{}
+			_, _ = r.Write([]byte(tt.args.data))
+
+			got, gotChanged, err := pr.TOML(r, tt.args.bmp, version.V("1.0.1"))
+
+			assert.NoError(t, err, fmt.Sprintf("TOML(%v)", tt.args.bmp))
+			assert.Equal(t, strings.Split(tt.want, "\n"), got, "TOML()")
+			tt.wantChanged(t, gotChanged, "TOML()")
+		})
+	}
+}
+
+func Test_process_TOML_readError(t *testing.T) {
+	pr := process{}
+	var r io.Reader = &__RWC{readError: assert.AnError}
+
+	_, _, err := pr.TOML(r, config.BumpFile{File: fsys.File("Cargo.toml")}, version.V("1.0.1"))
+
+	assert.Error(t, err)
+}
+
+func Test_tomlKey(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "version ", want: "version"},
+		{s: ` tool . "poetry".version`, want: "tool.poetry.version"},
+		{s: "a..b", want: ""},
+		{s: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.want, tomlKey(tt.s))
+		})
+	}
+}
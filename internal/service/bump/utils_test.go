@@ -20,6 +20,9 @@ type __rwMockArgs struct {
 
 	// data init for __RWC.buf
 	data []byte
+
+	// Exists result (for source)
+	exists bool
 }
 
 func __newRWMock(source __rwMockArgs, target __rwMockArgs) *__rwMock {
@@ -48,6 +51,8 @@ func __newRWMock(source __rwMockArgs, target __rwMockArgs) *__rwMock {
 
 	r.On("Write", mock.Anything, mock.Anything).Return(r.rwcTarget, target.writeErr)
 
+	r.On("Exists", mock.Anything).Return(source.exists)
+
 	return r
 }
 
@@ -69,6 +74,12 @@ func (rw *__rwMock) Read(patch string) (io.ReadCloser, error) {
 	return ret.Get(0).(io.ReadCloser), ret.Error(1)
 }
 
+func (rw *__rwMock) Exists(p string) bool {
+	ret := rw.Called(p)
+
+	return ret.Bool(0)
+}
+
 // __RWC - simple structure io.ReadWriteCloser.
 type __RWC struct {
 	buf        bytes.Buffer
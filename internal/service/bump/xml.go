@@ -0,0 +1,90 @@
+package bump
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/pkg/version"
+)
+
+// XML process XML file.
+// Replaces text of the elements by bump file paths, the rest of the file is not changed.
+func (process) XML(r io.Reader, bmp config.BumpFile, v version.V) (_ []string, changed bool, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false, fmt.Errorf("read file %s error: %w", bmp.File.String(), err)
+	}
+
+	spans, err := xmlTextSpans(data, bmp.XPaths())
+	if err != nil {
+		return nil, false, fmt.Errorf("parse file %s error: %w", bmp.File.String(), err)
+	}
+
+	var b bytes.Buffer
+
+	last := 0
+
+	for _, s := range spans {
+		b.Write(data[last:s[0]])
+		b.WriteString(v.FormatString())
+
+		last = s[1]
+		changed = true
+	}
+
+	b.Write(data[last:])
+
+	return splitLines(b.Bytes()), changed, nil
+}
+
+// xmlTextSpans returns byte positions [start, end) of not empty text of the elements by paths.
+// Whitespaces around the text are not included. CDATA sections are skipped.
+func xmlTextSpans(data []byte, paths []string) ([][2]int, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var stack []string
+	var spans [][2]int
+
+	for {
+		start := int(dec.InputOffset())
+
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return spans, nil
+			}
+
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			end := int(dec.InputOffset())
+			raw := data[start:end]
+
+			if bytes.HasPrefix(raw, []byte("<![CDATA[")) || !slices.Contains(paths, "/"+strings.Join(stack, "/")) {
+				continue
+			}
+
+			text := bytes.TrimSpace(raw)
+			if len(text) == 0 {
+				continue
+			}
+
+			s := start + bytes.Index(raw, text)
+			spans = append(spans, [2]int{s, s + len(text)})
+		}
+	}
+}
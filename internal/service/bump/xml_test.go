@@ -0,0 +1,107 @@
+package bump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/pkg/version"
+	"github.com/stretchr/testify/assert"
+)
+
+const __pomXML = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <!-- <version>0.0.1</version> -->
+  <parent>
+    <groupId>org.example</groupId>
+    <version>2.0.0</version>
+  </parent>
+  <version> 1.0.0 </version>
+  <dependencies>
+    <dependency>
+      <version>1.0.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+const __csprojXML = "<Project Sdk=\"Microsoft.NET.Sdk\">\r\n" +
+	"  <PropertyGroup>\r\n" +
+	"    <TargetFramework>net8.0</TargetFramework>\r\n" +
+	"    <Version>1.0.0</Version>\r\n" +
+	"  </PropertyGroup>\r\n" +
+	"  <ItemGroup>\r\n" +
+	"    <PackageReference Include=\"Serilog\" Version=\"1.0.0\" />\r\n" +
+	"  </ItemGroup>\r\n" +
+	"</Project>"
+
+func Test_process_XML(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		bmp         config.BumpFile
+		want        string
+		wantChanged assert.BoolAssertionFunc
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "pom",
+			data:        __pomXML,
+			bmp:         config.BumpFile{File: fsys.File("pom.xml")},
+			want:        strings.Replace(__pomXML, "<version> 1.0.0 </version>", "<version> 1.0.1 </version>", 1),
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "csproj",
+			data:        __csprojXML,
+			bmp:         config.BumpFile{File: fsys.File("App.csproj")},
+			want:        strings.Replace(__csprojXML, "<Version>1.0.0</Version>", "<Version>1.0.1</Version>", 1),
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "custom xpath",
+			data:        __pomXML,
+			bmp:         config.BumpFile{File: fsys.File("pom.xml"), XPath: "/project/parent/version"},
+			want:        strings.Replace(__pomXML, "2.0.0", "1.0.1", 1),
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "not found",
+			data:        __pomXML,
+			bmp:         config.BumpFile{File: fsys.File("pom.xml"), XPath: "/project/build/version"},
+			want:        __pomXML,
+			wantChanged: assert.False,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "parse error",
+			data:        "<project><version>1.0.0</project>",
+			bmp:         config.BumpFile{File: fsys.File("pom.xml")},
+			wantChanged: assert.False,
+			wantErr:     assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := process{}
+			r := &__RWC{}
+			_, _ = r.Write([]byte(tt.data))
+
+			got, gotChanged, err := pr.XML(r, tt.bmp, version.V("1.0.1"))
+			if !tt.wantErr(t, err, "XML()") {
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, strings.Split(strings.TrimSuffix(tt.want, "\n"), "\n"), got, "XML()")
+			}
+
+			tt.wantChanged(t, gotChanged, "XML()")
+		})
+	}
+}
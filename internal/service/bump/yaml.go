@@ -0,0 +1,81 @@
+package bump
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/pkg/version"
+	"github.com/klimby/version/pkg/yamlpath"
+	"gopkg.in/yaml.v3"
+)
+
+// YAML process YAML file.
+// Replaces scalar values of the bump file key paths, keeps comments and ordering.
+func (process) YAML(r io.Reader, bmp config.BumpFile, v version.V) (_ []string, changed bool, err error) {
+	scanner := newScanner(r)
+	var content []string
+
+	for scanner.Scan() {
+		content = append(content, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, false, fmt.Errorf("scan file %s error: %w", bmp.File.String(), err)
+	}
+
+	docs, err := yamlpath.Decode(strings.NewReader(strings.Join(content, "\n")))
+	if err != nil {
+		return nil, false, fmt.Errorf("parse file %s error: %w", bmp.File.String(), err)
+	}
+
+	for _, n := range yamlpath.Scalars(docs, bmp.Paths()...) {
+		if n.Line < 1 || n.Line > len(content) {
+			continue
+		}
+
+		if l, ok := yamlReplace(content[n.Line-1], n, v.FormatString()); ok {
+			content[n.Line-1] = l
+			changed = true
+		}
+	}
+
+	return content, changed, nil
+}
+
+// yamlReplace replaces single-line scalar node value in line with s.
+// Quotes of the value are kept. Returns false, if value is a block or multi-line scalar.
+func yamlReplace(line string, n *yaml.Node, s string) (string, bool) {
+	runes := []rune(line)
+	pos := n.Column - 1
+
+	if pos < 0 || pos >= len(runes) {
+		return line, false
+	}
+
+	switch n.Style {
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		quote := runes[pos]
+
+		for i := pos + 1; i < len(runes); i++ {
+			switch {
+			case quote == '"' && runes[i] == '\\':
+				i++
+			case quote == '\'' && runes[i] == '\'' && i+1 < len(runes) && runes[i+1] == '\'':
+				i++
+			case runes[i] == quote:
+				return string(runes[:pos+1]) + s + string(runes[i:]), true
+			}
+		}
+	case 0:
+		value := []rune(n.Value)
+		end := pos + len(value)
+
+		if end <= len(runes) && string(runes[pos:end]) == n.Value {
+			return string(runes[:pos]) + s + string(runes[end:]), true
+		}
+	}
+
+	return line, false
+}
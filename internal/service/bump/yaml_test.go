@@ -0,0 +1,110 @@
+package bump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/pkg/version"
+	"github.com/stretchr/testify/assert"
+)
+
+const __chartYaml = `apiVersion: v2
+# chart version
+version: 1.0.0
+name: app
+dependencies:
+  - name: redis
+    version: 1.0.0
+appVersion: "1.0.0" # app
+`
+
+const __openapiYaml = `openapi: 3.0.0
+info:
+  title: 'API'
+  version: 'v1.0.0'
+  description: |
+    version: 1.0.0
+`
+
+func Test_process_YAML(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		bmp         config.BumpFile
+		want        string
+		wantChanged assert.BoolAssertionFunc
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name: "chart default keys",
+			data: __chartYaml,
+			bmp:  config.BumpFile{File: fsys.File("Chart.yaml")},
+			want: strings.NewReplacer(
+				"version: 1.0.0\nname", "version: 1.0.1\nname",
+				`appVersion: "1.0.0"`, `appVersion: "1.0.1"`,
+			).Replace(__chartYaml),
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "openapi default key",
+			data:        __openapiYaml,
+			bmp:         config.BumpFile{File: fsys.File("openapi.yaml")},
+			want:        strings.Replace(__openapiYaml, "'v1.0.0'", "'1.0.1'", 1),
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "sequence key",
+			data:        __chartYaml,
+			bmp:         config.BumpFile{File: fsys.File("Chart.yaml"), Keys: []string{"dependencies.0.version"}},
+			want:        strings.Replace(__chartYaml, "    version: 1.0.0", "    version: 1.0.1", 1),
+			wantChanged: assert.True,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "block scalar",
+			data:        __openapiYaml,
+			bmp:         config.BumpFile{File: fsys.File("openapi.yaml"), Key: "info.description"},
+			want:        __openapiYaml,
+			wantChanged: assert.False,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "key not found",
+			data:        __chartYaml,
+			bmp:         config.BumpFile{File: fsys.File("Chart.yaml"), Key: "info.version"},
+			want:        __chartYaml,
+			wantChanged: assert.False,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "parse error",
+			data:        "version: [1.0.0",
+			bmp:         config.BumpFile{File: fsys.File("Chart.yaml")},
+			wantChanged: assert.False,
+			wantErr:     assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := process{}
+			r := &__RWC{}
+			_, _ = r.Write([]byte(tt.data))
+
+			got, gotChanged, err := pr.YAML(r, tt.bmp, version.V("1.0.1"))
+			if !tt.wantErr(t, err, "YAML()") {
+				return
+			}
+
+			if err == nil {
+				assert.Equal(t, strings.Split(strings.TrimSuffix(tt.want, "\n"), "\n"), got, "YAML()")
+			}
+
+			tt.wantChanged(t, gotChanged, "YAML()")
+		})
+	}
+}
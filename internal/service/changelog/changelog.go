@@ -10,6 +10,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"text/template"
 
 	"github.com/klimby/version/internal/config"
 	"github.com/klimby/version/internal/config/key"
@@ -34,6 +35,14 @@ type Generator struct {
 	bcp  backupSrv
 	f    fsys.File
 	nms  []config.CommitName
+	// tpl is a version section template file (default template if empty).
+	tpl fsys.File
+	// headerTpl is a header template file (default template if empty).
+	headerTpl fsys.File
+	// formats is a list of changelog file formats.
+	formats []config.ChangelogFormat
+	// style is a markdown changelog style.
+	style config.ChangelogStyle
 }
 
 // gitRepo is git repository.
@@ -66,14 +75,26 @@ type Args struct {
 	Backup      backupSrv
 	ConfigFile  fsys.File
 	CommitNames []config.CommitName
+	// Template is a version section template file. Optional.
+	Template fsys.File
+	// HeaderTemplate is a header template file. Optional.
+	HeaderTemplate fsys.File
+	// Formats is a list of changelog file formats. Default: markdown.
+	Formats []config.ChangelogFormat
+	// Style is a markdown changelog style. Default: conventional.
+	Style config.ChangelogStyle
 }
 
 // New creates new Generator.
 func New(args ...func(arg *Args)) *Generator {
 	a := &Args{
-		RW:         fsys.New(),
-		Backup:     backup.New(),
-		ConfigFile: fsys.File(viper.GetString(key.ChangelogFileName)),
+		RW:             fsys.New(),
+		Backup:         backup.New(),
+		ConfigFile:     fsys.File(viper.GetString(key.ChangelogFileName)),
+		Template:       fsys.File(viper.GetString(key.ChangelogTemplate)),
+		HeaderTemplate: fsys.File(viper.GetString(key.ChangelogHeaderTemplate)),
+		Formats:        config.ChangelogFormats(viper.GetStringSlice(key.ChangelogFormats)),
+		Style:          config.ChangelogStyle(viper.GetString(key.ChangelogStyle)),
 	}
 
 	for _, arg := range args {
@@ -90,59 +111,140 @@ func New(args ...func(arg *Args)) *Generator {
 		bcp:  a.Backup,
 		f:    a.ConfigFile,
 		nms:  a.CommitNames,
+
+		tpl:       a.Template,
+		headerTpl: a.HeaderTemplate,
+		formats:   a.Formats,
+		style:     a.Style,
 	}
 }
 
-// Add adds new version to changelog.
-func (g Generator) Add(nextV version.V) (err error) {
-	if err := g.bcp.Create(g.f.Path()); err != nil {
+// Add adds new version to changelog files.
+func (g Generator) Add(nextV version.V) error {
+	withNextV := func(args *git.CommitsArgs) {
+		args.NextV = nextV
+	}
+
+	fold, err := g.refold(nextV)
+	if err != nil {
 		return err
 	}
 
-	var b strings.Builder
+	for _, f := range g.formats {
+		if err := g.add(f, nextV, fold); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
 
-	if err := g.load(nextV, &b); err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			return g.generateAll(func(args *git.CommitsArgs) {
-				args.NextV = nextV
-			})
+			if err := g.generate(f, withNextV); err != nil {
+				return err
+			}
 		}
+	}
+
+	return nil
+}
+
+// add adds new version to changelog file in the format.
+// If fold is true, then pre-release sections of nextV are replaced by the nextV section.
+// Returns fs.ErrNotExist, if the file does not exist.
+func (g Generator) add(f config.ChangelogFormat, nextV version.V, fold bool) error {
+	file := formatFile(g.f, f)
 
+	if err := g.bcp.Create(file.Path()); err != nil {
 		return err
 	}
 
+	var (
+		data []byte
+		err  error
+	)
+
+	if f == config.FormatMarkdown {
+		var b strings.Builder
+
+		load := g.load
+		if g.style == config.StyleKeepAChangelog {
+			load = g.loadKeep
+		}
+
+		if err := load(nextV, fold, &b); err != nil {
+			return err
+		}
+
+		data = builder2B(b)
+	} else {
+		data, err = g.loadData(f, nextV, fold)
+		if err != nil {
+			return err
+		}
+	}
+
 	if viper.GetBool(key.DryRun) {
 		return nil
 	}
 
-	w, err := g.rw.Write(g.f.Path(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	if err := g.write(file, data); err != nil {
+		return err
+	}
+
+	if err := g.repo.Add(file); err != nil {
+		return fmt.Errorf("add changelog file error: %w", err)
+	}
+
+	console.Success(fmt.Sprintf("Changelog %s updated to %s", file.String(), nextV.FormatString()))
+
+	return nil
+}
+
+// refold returns true if pre-release sections must be folded into the nextV section.
+// It is true if changelog.foldPrerelease is set, nextV is a release and the last version is its pre-release.
+// Other sections of the changelog are not changed.
+func (g Generator) refold(nextV version.V) (bool, error) {
+	if !viper.GetBool(key.ChangelogFoldPrerelease) || nextV.IsPrerelease() {
+		return false, nil
+	}
+
+	commits, err := g.repo.Commits()
 	if err != nil {
-		return fmt.Errorf("open changelog file error: %w", err)
+		return false, err
 	}
 
-	defer func() {
-		if e := w.Close(); e != nil {
-			if err == nil {
-				err = fmt.Errorf("close config file error: %w", e)
-			}
+	for _, c := range commits {
+		if c.IsTag() {
+			return isFolded(nextV, c.Version), nil
 		}
-	}()
-
-	if _, err := w.Write(builder2B(b)); err != nil {
-		return fmt.Errorf("write changelog file error: %w", err)
 	}
 
-	if err := g.repo.Add(g.f); err != nil {
-		return fmt.Errorf("add changelog file error: %w", err)
+	return false, nil
+}
+
+// nextTags returns tags template data with the nextV section.
+// If fold is true, then commits of pre-release versions are folded into the nextV section.
+func (g Generator) nextTags(nextV version.V, fold bool) (tagsTpl, error) {
+	tags, err := g.tags(func(args *git.CommitsArgs) {
+		args.NextV = nextV
+		args.LastOnly = !fold
+	})
+	if err != nil {
+		return tags, err
 	}
 
-	console.Success(fmt.Sprintf("Changelog %s updated to %s", g.f.String(), nextV.FormatString()))
+	tags.Tags = tags.Tags[:1]
 
-	return nil
+	return tags, nil
+}
+
+// foldedSection returns true if the "## " line is a header of the pre-release section, folded into nextV.
+func foldedSection(line string, nextV version.V) bool {
+	m := _keepVersionRegexp.FindStringSubmatch(line)
+
+	return len(m) > 0 && isFolded(nextV, version.V(m[1]))
 }
 
 // load changes file.
-func (g Generator) load(nextV version.V, wr io.Writer) (err error) {
+// If fold is true, then pre-release sections of nextV are removed.
+func (g Generator) load(nextV version.V, fold bool, wr io.Writer) (err error) {
 	src, err := g.rw.Read(g.f.Path())
 	if err != nil {
 		return err
@@ -158,19 +260,34 @@ func (g Generator) load(nextV version.V, wr io.Writer) (err error) {
 
 	var b strings.Builder
 
-	if err := g.applyTemplate(&b, func(args *git.CommitsArgs) {
-		args.NextV = nextV
-		args.LastOnly = true
-	}); err != nil {
+	tags, err := g.nextTags(nextV, fold)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := g.sectionTemplate()
+	if err != nil {
+		return err
+	}
+
+	if err := tags.applyTemplate(&b, tmpl); err != nil {
 		return err
 	}
 
 	// read file by lines
 	scanner := bufio.NewScanner(src)
 
-	insert := false
+	insert, skip := false, false
 
 	for scanner.Scan() {
+		if fold && strings.HasPrefix(scanner.Text(), "## ") {
+			skip = foldedSection(scanner.Text(), nextV)
+		}
+
+		if skip {
+			continue
+		}
+
 		byteLine := append(scanner.Bytes(), '\n')
 		// Insert before first ## line.
 		if !insert && strings.HasPrefix(scanner.Text(), "##") {
@@ -214,47 +331,88 @@ func (g Generator) Generate() (err error) {
 	return g.generateAll()
 }
 
-// generateAll generates changelog.
-func (g Generator) generateAll(opt ...func(*git.CommitsArgs)) (err error) {
-	if err := g.rewrite(opt...); err != nil {
+// generateAll generates changelog files in all formats.
+func (g Generator) generateAll(opt ...func(*git.CommitsArgs)) error {
+	for _, f := range g.formats {
+		if err := g.generate(f, opt...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generate generates changelog file in the format.
+func (g Generator) generate(f config.ChangelogFormat, opt ...func(*git.CommitsArgs)) error {
+	file := formatFile(g.f, f)
+
+	if err := g.rewrite(f, opt...); err != nil {
 		return err
 	}
 
-	if err := g.repo.Add(g.f); err != nil {
+	if err := g.repo.Add(file); err != nil {
 		return fmt.Errorf("add changelog file error: %w", err)
 	}
 
-	console.Success(fmt.Sprintf("Changelog %s created", g.f.String()))
+	console.Success(fmt.Sprintf("Changelog %s created", file.String()))
 
 	return nil
 }
 
-// rewrite changelog.
-func (g Generator) rewrite(opt ...func(*git.CommitsArgs)) (err error) {
-	if err := g.bcp.Create(g.f.Path()); err != nil {
+// rewrite changelog file in the format.
+func (g Generator) rewrite(f config.ChangelogFormat, opt ...func(*git.CommitsArgs)) error {
+	file := formatFile(g.f, f)
+
+	if err := g.bcp.Create(file.Path()); err != nil {
 		return err
 	}
 
-	var b strings.Builder
-	b.WriteString("# " + viper.GetString(key.ChangelogTitle) + "\n\n")
+	var data []byte
 
-	b.WriteString("All notable changes to this project will be documented in this file. ")
-	b.WriteString("See [Conventional CommitsFromLast](https://www.conventionalcommits.org/en/v1.0.0/) for commit guidelines.\n")
+	if f == config.FormatMarkdown {
+		var b strings.Builder
 
-	if err := g.applyTemplate(&b, opt...); err != nil {
-		return err
+		if err := g.applyHeaderTemplate(&b); err != nil {
+			return err
+		}
+
+		apply := g.applyTemplate
+		if g.style == config.StyleKeepAChangelog {
+			apply = g.applyKeepTemplate
+		}
+
+		if err := apply(&b, opt...); err != nil {
+			return err
+		}
+
+		data = builder2B(b)
+	} else {
+		tags, err := g.tags(opt...)
+		if err != nil {
+			return err
+		}
+
+		data, err = newChangelogData(tags).marshal(f)
+		if err != nil {
+			return err
+		}
 	}
 
 	if viper.GetBool(key.Verbose) {
 		console.Info("Changelog created:")
-		console.Info(b.String())
+		console.Info(string(data))
 	}
 
 	if viper.GetBool(key.DryRun) {
 		return nil
 	}
 
-	w, err := g.rw.Write(g.f.Path(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	return g.write(file, data)
+}
+
+// write writes data to the changelog file.
+func (g Generator) write(file fsys.File, data []byte) (err error) {
+	w, err := g.rw.Write(file.Path(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
 	if err != nil {
 		return fmt.Errorf("open changelog file error: %w", err)
 	}
@@ -267,27 +425,110 @@ func (g Generator) rewrite(opt ...func(*git.CommitsArgs)) (err error) {
 		}
 	}()
 
-	if _, err := w.Write(builder2B(b)); err != nil {
+	if _, err := w.Write(data); err != nil {
 		return fmt.Errorf("write changelog file error: %w", err)
 	}
 
 	return nil
 }
 
+// loadData reads changelog data file in the format and adds new version to it.
+// If fold is true, then pre-release versions of nextV are removed.
+// Returns fs.ErrNotExist, if the file does not exist.
+func (g Generator) loadData(f config.ChangelogFormat, nextV version.V, fold bool) (_ []byte, err error) {
+	src, err := g.rw.Read(formatFile(g.f, f).Path())
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if e := src.Close(); e != nil {
+			if err == nil {
+				err = e
+			}
+		}
+	}()
+
+	b, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("read changelog file error: %w", err)
+	}
+
+	d, err := unmarshalData(f, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if fold {
+		d.removeFolded(nextV)
+	}
+
+	tags, err := g.nextTags(nextV, fold)
+	if err != nil {
+		return nil, err
+	}
+
+	// Last only commits do not contain the previous tag: take it from the file.
+	if last := &tags.Tags[len(tags.Tags)-1]; last.prev.Invalid() {
+		last.setPrev(d.latest(last.tag))
+	}
+
+	d.merge(newChangelogData(tags))
+
+	return d.marshal(f)
+}
+
+// tags returns tags template data from commits.
+// Returns ErrWarning, if there are no new commits.
+func (g Generator) tags(opt ...func(*git.CommitsArgs)) (tagsTpl, error) {
+	c, err := g.repo.Commits(opt...)
+	if err != nil {
+		return tagsTpl{}, err
+	}
+
+	t := newTagsTpl(g.nms, c)
+
+	if len(t.Tags) == 0 {
+		return t, fmt.Errorf("%w: no new commits", ErrWarning)
+	}
+
+	return t, nil
+}
+
 // applyTemplate applies template to writer.
 func (g Generator) applyTemplate(wr io.Writer, opt ...func(*git.CommitsArgs)) error {
-	c, err := g.repo.Commits(opt...)
+	tagsTpl, err := g.tags(opt...)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := g.sectionTemplate()
+	if err != nil {
+		return err
+	}
+
+	return tagsTpl.applyTemplate(wr, tmpl)
+}
+
+// applyHeaderTemplate applies header template to writer.
+func (g Generator) applyHeaderTemplate(wr io.Writer) error {
+	_, def := g.defaultTemplates()
+
+	text, err := g.readTemplate(g.headerTpl, def)
 	if err != nil {
 		return err
 	}
 
-	tagsTpl := newTagsTpl(g.nms, c)
+	tmpl, err := template.New("header").Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse header template error: %w", err)
+	}
 
-	if len(tagsTpl.Tags) == 0 {
-		return fmt.Errorf("%w: no new commits", ErrWarning)
+	if err := tmpl.Execute(wr, headerTpl{Title: viper.GetString(key.ChangelogTitle)}); err != nil {
+		return fmt.Errorf("execute header template error: %w", err)
 	}
 
-	return tagsTpl.applyTemplate(wr)
+	return nil
 }
 
 // Normalize changelog builder and convert to []byte.
@@ -3,8 +3,15 @@ package changelog
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_builder2B(t *testing.T) {
@@ -36,3 +43,77 @@ func Test_builder2B(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerator_Add_fold(t *testing.T) {
+	defer viper.Set(key.ChangelogFoldPrerelease, false)
+
+	viper.Set(key.DryRun, false)
+	viper.Set(key.RemoteURL, "")
+	viper.Set(key.ChangelogShowAuthor, false)
+	viper.Set(key.ChangelogFoldPrerelease, true)
+
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	rw := __newRWMock(map[string]string{"CHANGELOG.md": `# Changelog
+
+## 1.1.0-rc.1 (2024-01-02)
+
+### Features
+
+* second (3333333)
+
+## 1.0.0 (2024-01-01)
+
+Hand-edited release description.
+
+### Features
+
+* first, edited by hand (1111111)
+`})
+
+	bcp := &__backupMock{}
+	bcp.On("Create", mock.Anything).Return(nil)
+
+	g := Generator{
+		repo: __newGitRepoMock([]git.Commit{
+			{Hash: "5555555", Message: "fix: fourth"},
+			{Hash: "4444444", Message: "chore(release): 1.1.0-rc.1", Version: "1.1.0-rc.1", Date: date},
+			{Hash: "3333333", Message: "feat: second"},
+			{Hash: "2222222", Message: "chore(release): 1.0.0", Version: "1.0.0", Date: date},
+			{Hash: "1111111", Message: "feat: first"},
+		}, nil),
+		rw:  rw,
+		bcp: bcp,
+		f:   "CHANGELOG.md",
+		nms: []config.CommitName{
+			{Type: "feat", Name: "Features"},
+			{Type: "fix", Name: "Bug Fixes"},
+		},
+		formats: []config.ChangelogFormat{config.FormatMarkdown},
+	}
+
+	if !assert.NoError(t, g.Add("1.1.0"), "Add()") {
+		return
+	}
+
+	assert.Equal(t, `# Changelog
+
+## 1.1.0 (`+time.Now().Format("2006-01-02")+`)
+
+### Features
+
+* second (3333333)
+
+### Bug Fixes
+
+* fourth (5555555)
+
+## 1.0.0 (2024-01-01)
+
+Hand-edited release description.
+
+### Features
+
+* first, edited by hand (1111111)
+`, rw.files[fsys.File("CHANGELOG.md").Path()].String(), "Add()")
+}
@@ -0,0 +1,275 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// changelogData is a machine-readable changelog (json, yaml).
+type changelogData struct {
+	// Title is a changelog title.
+	Title string `json:"title" yaml:"title"`
+	// Versions is a list of versions from newest to oldest.
+	Versions []versionData `json:"versions" yaml:"versions"`
+}
+
+// versionData is a version section of machine-readable changelog.
+type versionData struct {
+	// Version is a version (1.2.0).
+	Version string `json:"version" yaml:"version"`
+	// Tag is a git tag name (v1.2.0).
+	Tag string `json:"tag" yaml:"tag"`
+	// Previous is a previous version (empty for the first version).
+	Previous string `json:"previous,omitempty" yaml:"previous,omitempty"`
+	// Date is a version date (2006-01-02).
+	Date string `json:"date" yaml:"date"`
+	// CompareURL is a compare with previous version URL (empty, if remote URL is not set).
+	CompareURL string `json:"compareUrl,omitempty" yaml:"compareUrl,omitempty"`
+	// BreakingChanges is a list of breaking changes.
+	BreakingChanges []commitData `json:"breakingChanges" yaml:"breakingChanges"`
+	// Blocks is a list of not empty commit blocks by commit types.
+	Blocks []blockData `json:"blocks" yaml:"blocks"`
+}
+
+// blockData is a commit type block of machine-readable changelog.
+type blockData struct {
+	// Type is a commit type (feat, fix, etc.).
+	Type string `json:"type" yaml:"type"`
+	// Name is a commit type name (Features, Bug Fixes, etc.).
+	Name string `json:"name" yaml:"name"`
+	// Commits is a list of commits.
+	Commits []commitData `json:"commits" yaml:"commits"`
+}
+
+// commitData is a commit of machine-readable changelog.
+type commitData struct {
+	Type       string   `json:"type" yaml:"type"`
+	Scope      string   `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Message    string   `json:"message" yaml:"message"`
+	Body       []string `json:"body,omitempty" yaml:"body,omitempty"`
+	Hash       string   `json:"hash" yaml:"hash"`
+	URL        string   `json:"url,omitempty" yaml:"url,omitempty"`
+	Author     string   `json:"author,omitempty" yaml:"author,omitempty"`
+	AuthorHref string   `json:"authorHref,omitempty" yaml:"authorHref,omitempty"`
+}
+
+// newChangelogData returns a new changelogData from tags.
+func newChangelogData(t tagsTpl) changelogData {
+	remoteURL := viper.GetString(key.RemoteURL)
+
+	d := changelogData{
+		Title:    viper.GetString(key.ChangelogTitle),
+		Versions: make([]versionData, 0, len(t.Tags)),
+	}
+
+	for _, tag := range t.Tags {
+		d.Versions = append(d.Versions, newVersionData(tag, remoteURL))
+	}
+
+	return d
+}
+
+// newVersionData returns a new versionData from tag.
+func newVersionData(t tagTpl, remoteURL string) versionData {
+	v := versionData{
+		Version:         t.tag.FormatString(),
+		Tag:             git.TagName(t.tag),
+		Date:            t.Date,
+		CompareURL:      compareURL(remoteURL, t),
+		BreakingChanges: make([]commitData, 0, len(t.BreakingChanges)),
+		Blocks:          []blockData{},
+	}
+
+	if !t.prev.Invalid() {
+		v.Previous = t.prev.FormatString()
+	}
+
+	for _, c := range t.BreakingChanges {
+		v.BreakingChanges = append(v.BreakingChanges, newCommitData(c, remoteURL))
+	}
+
+	for _, b := range t.Blocks {
+		if len(b.Commits) == 0 {
+			continue
+		}
+
+		block := blockData{
+			Type:    b.CommitType,
+			Name:    b.Name,
+			Commits: make([]commitData, 0, len(b.Commits)),
+		}
+
+		for _, c := range b.Commits {
+			block.Commits = append(block.Commits, newCommitData(c, remoteURL))
+		}
+
+		v.Blocks = append(v.Blocks, block)
+	}
+
+	return v
+}
+
+// newCommitData returns a new commitData from commit.
+func newCommitData(c commitTpl, remoteURL string) commitData {
+	return commitData{
+		Type:       c.CommitType,
+		Scope:      c.Scope,
+		Message:    c.Message,
+		Body:       c.Body,
+		Hash:       c.Hash,
+		URL:        commitURL(remoteURL, c.Hash),
+		Author:     c.Author,
+		AuthorHref: c.AuthorHref,
+	}
+}
+
+// merge adds new versions to the top of the changelog.
+// Existing versions with the same version are replaced.
+func (d *changelogData) merge(n changelogData) {
+	versions := make([]versionData, 0, len(n.Versions)+len(d.Versions))
+	versions = append(versions, n.Versions...)
+
+	for _, v := range d.Versions {
+		if !n.has(v.Version) {
+			versions = append(versions, v)
+		}
+	}
+
+	d.Title = n.Title
+	d.Versions = versions
+}
+
+// removeFolded removes pre-release versions, folded into the release version v.
+func (d *changelogData) removeFolded(v version.V) {
+	versions := make([]versionData, 0, len(d.Versions))
+
+	for _, dv := range d.Versions {
+		if !isFolded(v, version.V(dv.Version)) {
+			versions = append(versions, dv)
+		}
+	}
+
+	d.Versions = versions
+}
+
+// latest returns the latest version in the changelog, except the version v.
+// Returns empty version, if the changelog has no other versions.
+func (d changelogData) latest(v version.V) version.V {
+	for _, dv := range d.Versions {
+		if dv.Version != v.FormatString() {
+			return version.V(dv.Version)
+		}
+	}
+
+	return ""
+}
+
+// has returns true if the changelog has the version.
+func (d changelogData) has(v string) bool {
+	for _, dv := range d.Versions {
+		if dv.Version == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// marshal returns the changelog in the format.
+func (d changelogData) marshal(f config.ChangelogFormat) ([]byte, error) {
+	switch f {
+	case config.FormatJSON:
+		b, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal json changelog error: %w", err)
+		}
+
+		return append(b, '\n'), nil
+	case config.FormatYAML:
+		b, err := yaml.Marshal(d)
+		if err != nil {
+			return nil, fmt.Errorf("marshal yaml changelog error: %w", err)
+		}
+
+		return b, nil
+	default:
+		return nil, fmt.Errorf("changelog format %s is not a data format", f)
+	}
+}
+
+// unmarshalData returns the changelog from data in the format.
+func unmarshalData(f config.ChangelogFormat, b []byte) (changelogData, error) {
+	var d changelogData
+
+	switch f {
+	case config.FormatJSON:
+		if err := json.Unmarshal(b, &d); err != nil {
+			return d, fmt.Errorf("unmarshal json changelog error: %w", err)
+		}
+	case config.FormatYAML:
+		if err := yaml.Unmarshal(b, &d); err != nil {
+			return d, fmt.Errorf("unmarshal yaml changelog error: %w", err)
+		}
+	default:
+		return d, fmt.Errorf("changelog format %s is not a data format", f)
+	}
+
+	return d, nil
+}
+
+// formatFile returns the changelog file for the format.
+// Markdown file is the changelog file, json and yaml files have the same name with .json and .yaml extensions.
+func formatFile(f fsys.File, format config.ChangelogFormat) fsys.File {
+	if format == config.FormatMarkdown {
+		return f
+	}
+
+	s := f.String()
+
+	return fsys.File(strings.TrimSuffix(s, filepath.Ext(s)) + "." + format.String())
+}
+
+// compareURL returns a compare with previous version URL (with HEAD for unreleased commits).
+// Returns empty string, if remote URL or previous version is not set.
+func compareURL(remoteURL string, t tagTpl) string {
+	if remoteURL == "" || t.prev.Invalid() {
+		return ""
+	}
+
+	head := "HEAD"
+	if !t.tag.Invalid() {
+		head = git.TagName(t.tag)
+	}
+
+	u, err := url.JoinPath(remoteURL, "compare", fmt.Sprintf("%s...%s", git.TagName(t.prev), head))
+	if err != nil {
+		return ""
+	}
+
+	return u
+}
+
+// commitURL returns a commit URL. Returns empty string, if remote URL is not set.
+func commitURL(remoteURL, hash string) string {
+	if remoteURL == "" {
+		return ""
+	}
+
+	u, err := url.JoinPath(remoteURL, "commit", hash)
+	if err != nil {
+		return ""
+	}
+
+	return u
+}
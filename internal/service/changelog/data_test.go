@@ -0,0 +1,221 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_formatFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      fsys.File
+		format config.ChangelogFormat
+		want   fsys.File
+	}{
+		{
+			name:   "markdown",
+			f:      "CHANGELOG.md",
+			format: config.FormatMarkdown,
+			want:   "CHANGELOG.md",
+		},
+		{
+			name:   "json",
+			f:      "backend/CHANGELOG.md",
+			format: config.FormatJSON,
+			want:   "backend/CHANGELOG.json",
+		},
+		{
+			name:   "yaml without extension",
+			f:      "CHANGES",
+			format: config.FormatYAML,
+			want:   "CHANGES.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatFile(tt.f, tt.format), "formatFile()")
+		})
+	}
+}
+
+func Test_newVersionData(t *testing.T) {
+	viper.Set(key.ChangelogShowBody, true)
+
+	tag := newTagTpl([]config.CommitName{
+		{Type: "feat", Name: "Features"},
+		{Type: "fix", Name: "Bug Fixes"},
+	}, "1.1.0", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	tag.setPrev("1.0.0")
+	tag.addCommit(git.Commit{Hash: "abcdef1234", Message: "feat(api): message\n\nbody", Author: "John"})
+	tag.addCommit(git.Commit{Hash: "1234abcdef", Message: "fix!: breaking"})
+
+	got := newVersionData(tag, "https://github.com/company/project")
+
+	assert.Equal(t, "1.1.0", got.Version, "Version")
+	assert.Equal(t, "v1.1.0", got.Tag, "Tag")
+	assert.Equal(t, "1.0.0", got.Previous, "Previous")
+	assert.Equal(t, "2024-01-02", got.Date, "Date")
+	assert.Equal(t, "https://github.com/company/project/compare/v1.0.0...v1.1.0", got.CompareURL, "CompareURL")
+
+	assert.Len(t, got.BreakingChanges, 1, "BreakingChanges")
+	assert.Equal(t, "breaking", got.BreakingChanges[0].Message, "BreakingChanges message")
+
+	assert.Len(t, got.Blocks, 1, "Blocks (empty blocks are skipped)")
+	assert.Equal(t, "feat", got.Blocks[0].Type, "Blocks type")
+	assert.Equal(t, commitData{
+		Type:    "feat",
+		Scope:   "api",
+		Message: "message",
+		Body:    []string{"body"},
+		Hash:    "abcdef1234",
+		URL:     "https://github.com/company/project/commit/abcdef1234",
+		Author:  "John",
+	}, got.Blocks[0].Commits[0], "Blocks commit")
+}
+
+func Test_changelogData_merge(t *testing.T) {
+	d := changelogData{
+		Title: "Old",
+		Versions: []versionData{
+			{Version: "1.1.0", Date: "old"},
+			{Version: "1.0.0"},
+		},
+	}
+
+	d.merge(changelogData{
+		Title:    "Changelog",
+		Versions: []versionData{{Version: "1.2.0"}, {Version: "1.1.0", Date: "new"}},
+	})
+
+	assert.Equal(t, "Changelog", d.Title, "merge() title")
+	assert.Equal(t, []versionData{
+		{Version: "1.2.0"},
+		{Version: "1.1.0", Date: "new"},
+		{Version: "1.0.0"},
+	}, d.Versions, "merge() versions")
+
+	assert.Equal(t, version.V("1.1.0"), d.latest("1.2.0"), "latest()")
+	assert.Equal(t, version.V("1.2.0"), d.latest("1.3.0"), "latest()")
+}
+
+func Test_changelogData_marshal(t *testing.T) {
+	d := changelogData{
+		Title: "Changelog",
+		Versions: []versionData{
+			{
+				Version:         "1.0.0",
+				Tag:             "v1.0.0",
+				Date:            "2024-01-02",
+				BreakingChanges: []commitData{},
+				Blocks: []blockData{
+					{Type: "feat", Name: "Features", Commits: []commitData{{Type: "feat", Message: "message", Hash: "abc"}}},
+				},
+			},
+		},
+	}
+
+	for _, f := range []config.ChangelogFormat{config.FormatJSON, config.FormatYAML} {
+		t.Run(f.String(), func(t *testing.T) {
+			b, err := d.marshal(f)
+			if !assert.NoError(t, err, "marshal()") {
+				return
+			}
+
+			got, err := unmarshalData(f, b)
+			if !assert.NoError(t, err, "unmarshalData()") {
+				return
+			}
+
+			assert.Equal(t, d, got, "unmarshalData()")
+		})
+	}
+
+	_, err := d.marshal(config.FormatMarkdown)
+	assert.Error(t, err, "marshal() markdown")
+}
+
+func TestGenerator_Add_data(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "2222222", Message: "chore(release): 1.1.0", Version: "1.1.0", Date: time.Now()},
+		{Hash: "1111111", Message: "feat: message"},
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "add to existing file",
+			files: map[string]string{"CHANGELOG.json": `{"title":"Changelog","versions":[{"version":"1.0.0","tag":"v1.0.0"}]}`},
+			want:  []string{"1.1.0", "1.0.0"},
+		},
+		{
+			name: "file not exists",
+			want: []string{"1.1.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.DryRun, false)
+
+			rw := __newRWMock(tt.files)
+			bcp := &__backupMock{}
+			bcp.On("Create", mock.Anything).Return(nil)
+
+			g := Generator{
+				repo:    __newGitRepoMock(commits, nil),
+				rw:      rw,
+				bcp:     bcp,
+				f:       "CHANGELOG.md",
+				nms:     []config.CommitName{{Type: "feat", Name: "Features"}},
+				formats: []config.ChangelogFormat{config.FormatJSON},
+			}
+
+			if !assert.NoError(t, g.Add("1.1.0"), "Add()") {
+				return
+			}
+
+			d, err := unmarshalData(config.FormatJSON, rw.files[fsys.File("CHANGELOG.json").Path()].Bytes())
+			if !assert.NoError(t, err, "unmarshalData()") {
+				return
+			}
+
+			got := make([]string, 0, len(d.Versions))
+			for _, v := range d.Versions {
+				got = append(got, v.Version)
+			}
+
+			assert.Equal(t, tt.want, got, "Add() versions")
+			assert.Len(t, d.Versions[0].Blocks, 1, "Add() blocks")
+		})
+	}
+}
+
+func Test_changelogData_removeFolded(t *testing.T) {
+	d := changelogData{Versions: []versionData{
+		{Version: "1.1.0-rc.2"},
+		{Version: "1.1.0-rc.1"},
+		{Version: "1.0.0"},
+		{Version: "1.0.0-rc.1"},
+	}}
+
+	d.removeFolded("1.1.0")
+
+	got := make([]string, 0, len(d.Versions))
+	for _, v := range d.Versions {
+		got = append(got, v.Version)
+	}
+
+	assert.Equal(t, []string{"1.0.0", "1.0.0-rc.1"}, got, "removeFolded()")
+}
@@ -0,0 +1,558 @@
+package changelog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/console"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/klimby/version/pkg/convert"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+)
+
+var (
+	_keepUnreleasedRegexp = regexp.MustCompile(`(?i)^##\s+\[?unreleased\]?`)
+	_keepVersionRegexp    = regexp.MustCompile(`^##\s+\[?([^\]\s]+)\]?`)
+	_keepLinkRegexp       = regexp.MustCompile(`^\[([^\]]+)\]:\s*(\S+)`)
+)
+
+// _keepUnreleasedTitle is an Unreleased section title for keepachangelog style.
+const _keepUnreleasedTitle = "## [Unreleased]"
+
+// keepTag returns the tag with commit blocks grouped by Keep a Changelog categories.
+func keepTag(nms []config.CommitName, t tagTpl) tagTpl {
+	categories := make(map[string]config.KeepCategory, len(nms))
+
+	for _, nm := range nms {
+		categories[nm.Type] = nm.KeepCategory()
+	}
+
+	blocks := make([]tagTplBlock, len(config.KeepCategories))
+
+	for i, c := range config.KeepCategories {
+		blocks[i] = tagTplBlock{
+			Name:    c.String(),
+			Commits: []commitTpl{},
+		}
+	}
+
+	for _, b := range t.Blocks {
+		for i := range blocks {
+			if blocks[i].Name == categories[b.CommitType].String() {
+				blocks[i].Commits = append(blocks[i].Commits, b.Commits...)
+			}
+		}
+	}
+
+	t.Blocks = blocks
+
+	return t
+}
+
+// keepSection is a changelog version section, grouped by categories (### headers).
+type keepSection struct {
+	// title is a section title line (## [1.2.0] - 2024-01-02).
+	title string
+	// text is a section text before the first category.
+	text []string
+	// categories is a list of categories in section order.
+	categories []string
+	// items is a list of category lines by category.
+	items map[string][]string
+}
+
+// newKeepSection returns a new keepSection from the tag template.
+func newKeepSection(t tagTpl, tmpl *template.Template) (keepSection, error) {
+	var b strings.Builder
+
+	if err := t.applyTemplate(&b, tmpl); err != nil {
+		return keepSection{}, err
+	}
+
+	lines := strings.Split(b.String(), "\n")
+
+	for i, l := range lines {
+		if strings.HasPrefix(l, "## ") {
+			s := parseKeepSection(lines[i+1:])
+			s.title = l
+
+			return s, nil
+		}
+	}
+
+	return keepSection{}, errors.New(`version section template must contain "## " header`)
+}
+
+// parseKeepSection returns a new keepSection from section lines (without title).
+func parseKeepSection(lines []string) keepSection {
+	s := keepSection{
+		items: map[string][]string{},
+	}
+
+	// header is true after category header: blank lines are skipped, repeated categories are joined.
+	current, header := "", false
+
+	for _, l := range lines {
+		if name, ok := strings.CutPrefix(l, "### "); ok {
+			if current != "" {
+				s.items[current] = trimBlank(s.items[current])
+			}
+
+			current, header = strings.TrimSpace(name), true
+
+			if _, ok := s.items[current]; !ok {
+				s.categories = append(s.categories, current)
+				s.items[current] = []string{}
+			}
+
+			continue
+		}
+
+		switch {
+		case current == "":
+			s.text = append(s.text, l)
+		case header && strings.TrimSpace(l) == "":
+			continue
+		default:
+			header = false
+			s.items[current] = append(s.items[current], l)
+		}
+	}
+
+	s.text = trimBlank(s.text)
+
+	for c, items := range s.items {
+		s.items[c] = trimBlank(items)
+	}
+
+	return s
+}
+
+// merge adds text and category items of the section o to the section.
+func (s *keepSection) merge(o keepSection) {
+	if s.items == nil {
+		s.items = map[string][]string{}
+	}
+
+	s.text = append(s.text, o.text...)
+
+	for _, c := range o.categories {
+		if _, ok := s.items[c]; !ok {
+			s.categories = append(s.categories, c)
+		}
+
+		s.items[c] = append(s.items[c], o.items[c]...)
+	}
+}
+
+// render writes the section to the builder.
+// Keep a Changelog categories are written first in standard order, then other categories in section order.
+func (s keepSection) render(b *strings.Builder) {
+	b.WriteString(s.title)
+	b.WriteString("\n\n")
+
+	if len(s.text) > 0 {
+		b.WriteString(strings.Join(s.text, "\n"))
+		b.WriteString("\n\n")
+	}
+
+	categories := make([]string, 0, len(s.categories))
+	standard := make(map[string]bool, len(config.KeepCategories))
+
+	for _, c := range config.KeepCategories {
+		standard[c.String()] = true
+
+		if _, ok := s.items[c.String()]; ok {
+			categories = append(categories, c.String())
+		}
+	}
+
+	for _, c := range s.categories {
+		if !standard[c] {
+			categories = append(categories, c)
+		}
+	}
+
+	for _, c := range categories {
+		if len(s.items[c]) == 0 {
+			continue
+		}
+
+		b.WriteString("### ")
+		b.WriteString(c)
+		b.WriteString("\n\n")
+		b.WriteString(strings.Join(s.items[c], "\n"))
+		b.WriteString("\n\n")
+	}
+}
+
+// keepLink is a markdown link reference in the changelog footer ([1.2.0]: https://...).
+type keepLink struct {
+	name string
+	url  string
+}
+
+// keepDoc is a parsed keepachangelog file.
+type keepDoc struct {
+	// header is a list of lines before the first section.
+	header []string
+	// unreleased is an Unreleased section.
+	unreleased keepSection
+	// sections is a list of version sections lines.
+	sections []string
+	// links is a list of footer link references.
+	links []keepLink
+	// prev is the last version in the changelog.
+	prev version.V
+}
+
+// parseKeepDoc returns a new keepDoc from the changelog file.
+func parseKeepDoc(r io.Reader) (keepDoc, error) {
+	var (
+		d     keepDoc
+		lines []string
+	)
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return d, err
+	}
+
+	// footer: link references and blank lines at the end of the file.
+	end := len(lines)
+
+	for end > 0 {
+		l := strings.TrimSpace(lines[end-1])
+		if l != "" && !_keepLinkRegexp.MatchString(l) {
+			break
+		}
+
+		end--
+	}
+
+	for _, l := range lines[end:] {
+		if m := _keepLinkRegexp.FindStringSubmatch(strings.TrimSpace(l)); len(m) > 0 {
+			d.links = append(d.links, keepLink{name: m[1], url: m[2]})
+		}
+	}
+
+	inUnreleased := false
+
+	var unreleased []string
+
+	for _, l := range lines[:end] {
+		if strings.HasPrefix(l, "## ") {
+			inUnreleased = _keepUnreleasedRegexp.MatchString(l)
+
+			if !inUnreleased && d.prev == "" {
+				if m := _keepVersionRegexp.FindStringSubmatch(l); len(m) > 0 && !version.V(m[1]).Invalid() {
+					d.prev = version.V(m[1])
+				}
+			}
+
+			if inUnreleased {
+				continue
+			}
+		}
+
+		switch {
+		case inUnreleased:
+			unreleased = append(unreleased, l)
+		case len(d.sections) == 0 && !strings.HasPrefix(l, "## "):
+			d.header = append(d.header, l)
+		default:
+			d.sections = append(d.sections, l)
+		}
+	}
+
+	d.header = trimBlank(d.header)
+	d.sections = trimBlank(d.sections)
+	d.unreleased = parseKeepSection(unreleased)
+
+	return d, nil
+}
+
+// removeFolded removes sections and footer links of pre-release versions, folded into the release version v.
+func (d *keepDoc) removeFolded(v version.V) {
+	sections := make([]string, 0, len(d.sections))
+	skip := false
+
+	for _, l := range d.sections {
+		if strings.HasPrefix(l, "## ") {
+			skip = foldedSection(l, v)
+		}
+
+		if !skip {
+			sections = append(sections, l)
+		}
+	}
+
+	d.sections = trimBlank(sections)
+	d.prev = ""
+
+	for _, l := range d.sections {
+		if m := _keepVersionRegexp.FindStringSubmatch(l); len(m) > 0 && !version.V(m[1]).Invalid() {
+			d.prev = version.V(m[1])
+
+			break
+		}
+	}
+
+	links := make([]keepLink, 0, len(d.links))
+
+	for _, l := range d.links {
+		if !isFolded(v, version.V(l.name)) {
+			links = append(links, l)
+		}
+	}
+
+	d.links = links
+}
+
+// loadKeep reads keepachangelog file and writes it with the nextV section to writer.
+// Unreleased entries are moved into the nextV section, footer compare links are updated.
+// If fold is true, then pre-release sections of nextV are removed.
+func (g Generator) loadKeep(nextV version.V, fold bool, wr io.Writer) (err error) {
+	src, err := g.rw.Read(g.f.Path())
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := src.Close(); e != nil {
+			if err == nil {
+				err = e
+			}
+		}
+	}()
+
+	d, err := parseKeepDoc(src)
+	if err != nil {
+		return err
+	}
+
+	if fold {
+		d.removeFolded(nextV)
+	}
+
+	tags, err := g.nextTags(nextV, fold)
+	if err != nil {
+		return err
+	}
+
+	tag := tags.Tags[0]
+
+	// Last only commits do not contain the previous tag: take it from the file.
+	if tag.prev.Invalid() {
+		tag.setPrev(d.prev)
+	}
+
+	tmpl, err := g.sectionTemplate()
+	if err != nil {
+		return err
+	}
+
+	s, err := newKeepSection(keepTag(g.nms, tag), tmpl)
+	if err != nil {
+		return err
+	}
+
+	section := d.unreleased
+	section.title = s.title
+	section.merge(s)
+
+	var b strings.Builder
+
+	if len(d.header) > 0 {
+		b.WriteString(strings.Join(d.header, "\n"))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(_keepUnreleasedTitle)
+	b.WriteString("\n\n")
+
+	section.render(&b)
+
+	if len(d.sections) > 0 {
+		b.WriteString(strings.Join(d.sections, "\n"))
+		b.WriteString("\n\n")
+	}
+
+	writeKeepLinks(&b, updateKeepLinks(d.links, tag))
+
+	if _, err := wr.Write(convert.S2B(b.String())); err != nil {
+		return err
+	}
+
+	if viper.GetBool(key.Verbose) {
+		var sb strings.Builder
+
+		section.render(&sb)
+
+		console.Info("Changelog changed:")
+		console.Info(sb.String())
+	}
+
+	return nil
+}
+
+// applyKeepTemplate applies keepachangelog template to writer: Unreleased section, versions and footer links.
+// Unreleased entries from the existing changelog file are kept (or moved into the next version section).
+func (g Generator) applyKeepTemplate(wr io.Writer, opt ...func(*git.CommitsArgs)) error {
+	a := &git.CommitsArgs{}
+
+	for _, o := range opt {
+		o(a)
+	}
+
+	tags, err := g.tags(opt...)
+	if err != nil {
+		return err
+	}
+
+	unreleased, err := g.readKeepUnreleased()
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := g.sectionTemplate()
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+
+	b.WriteString("\n")
+
+	if a.NextV.Empty() {
+		unreleased.title = _keepUnreleasedTitle
+		unreleased.render(&b)
+	} else {
+		b.WriteString(_keepUnreleasedTitle)
+		b.WriteString("\n\n")
+	}
+
+	var links []keepLink
+
+	for i := len(tags.Tags) - 1; i >= 0; i-- {
+		links = updateKeepLinks(links, tags.Tags[i])
+	}
+
+	for i, t := range tags.Tags {
+		s, err := newKeepSection(keepTag(g.nms, t), tmpl)
+		if err != nil {
+			return err
+		}
+
+		if i == 0 && !a.NextV.Empty() && t.tag.Equal(a.NextV) {
+			unreleased.title = s.title
+			unreleased.merge(s)
+			s = unreleased
+		}
+
+		s.render(&b)
+	}
+
+	writeKeepLinks(&b, links)
+
+	_, err = wr.Write(convert.S2B(b.String()))
+
+	return err
+}
+
+// readKeepUnreleased returns Unreleased section from the existing changelog file.
+func (g Generator) readKeepUnreleased() (_ keepSection, err error) {
+	src, err := g.rw.Read(g.f.Path())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return keepSection{}, nil
+		}
+
+		return keepSection{}, err
+	}
+
+	defer func() {
+		if e := src.Close(); e != nil {
+			if err == nil {
+				err = e
+			}
+		}
+	}()
+
+	d, err := parseKeepDoc(src)
+	if err != nil {
+		return keepSection{}, err
+	}
+
+	return d.unreleased, nil
+}
+
+// updateKeepLinks returns footer links with the tag version link and Unreleased link, compared with the tag.
+// If remote URL is not set, then links are not changed.
+func updateKeepLinks(links []keepLink, t tagTpl) []keepLink {
+	remoteURL := viper.GetString(key.RemoteURL)
+
+	if remoteURL == "" || t.tag.Invalid() {
+		return links
+	}
+
+	res := make([]keepLink, 0, len(links)+2)
+
+	if u, err := url.JoinPath(remoteURL, "compare", fmt.Sprintf("%s...HEAD", git.TagName(t.tag))); err == nil {
+		res = append(res, keepLink{name: "unreleased", url: u})
+	}
+
+	u := compareURL(remoteURL, t)
+	if u == "" {
+		u, _ = url.JoinPath(remoteURL, "releases", "tag", git.TagName(t.tag))
+	}
+
+	if u != "" {
+		res = append(res, keepLink{name: t.tag.FormatString(), url: u})
+	}
+
+	for _, l := range links {
+		if strings.EqualFold(l.name, "unreleased") || l.name == t.tag.FormatString() {
+			continue
+		}
+
+		res = append(res, l)
+	}
+
+	return res
+}
+
+// writeKeepLinks writes footer links to the builder.
+func writeKeepLinks(b *strings.Builder, links []keepLink) {
+	for _, l := range links {
+		b.WriteString(fmt.Sprintf("[%s]: %s\n", l.name, l.url))
+	}
+}
+
+// trimBlank removes blank lines from the start and the end of lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
@@ -0,0 +1,249 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_keepTag(t *testing.T) {
+	nms := []config.CommitName{
+		{Type: "feat", Name: "Features"},
+		{Type: "fix", Name: "Bug Fixes"},
+		{Type: "docs", Name: "Documentation"},
+		{Type: "sec", Name: "Security", Category: config.CategorySecurity},
+	}
+
+	tag := newTagTpl(nms, "1.0.0", time.Now())
+	tag.addCommit(git.Commit{Message: "feat: feature"})
+	tag.addCommit(git.Commit{Message: "fix: fix"})
+	tag.addCommit(git.Commit{Message: "docs: docs"})
+	tag.addCommit(git.Commit{Message: "sec: cve"})
+
+	got := keepTag(nms, tag)
+
+	want := map[string][]string{
+		"Added":      {"feature"},
+		"Changed":    {"docs"},
+		"Deprecated": {},
+		"Removed":    {},
+		"Fixed":      {"fix"},
+		"Security":   {"cve"},
+	}
+
+	assert.Len(t, got.Blocks, len(config.KeepCategories), "keepTag() blocks")
+
+	for _, b := range got.Blocks {
+		messages := make([]string, 0, len(b.Commits))
+		for _, c := range b.Commits {
+			messages = append(messages, c.Message)
+		}
+
+		assert.Equal(t, want[b.Name], messages, "keepTag() block %s", b.Name)
+	}
+}
+
+func Test_keepSection(t *testing.T) {
+	unreleased := parseKeepSection(strings.Split(`
+Some text.
+
+### Security
+
+- Manual security
+
+### Added
+
+- Manual feature
+`, "\n"))
+
+	generated := parseKeepSection(strings.Split(`
+### Changed
+
+- **BREAKING:** breaking
+
+### Added
+
+- feature
+  - body
+
+### Changed
+
+- change
+
+### Custom
+
+- custom
+`, "\n"))
+
+	assert.Equal(t, []string{"Some text."}, unreleased.text, "parseKeepSection() text")
+	assert.Equal(t, []string{"Changed", "Added", "Custom"}, generated.categories, "parseKeepSection() categories")
+	assert.Equal(t, []string{"- **BREAKING:** breaking", "- change"}, generated.items["Changed"], "parseKeepSection() repeated category")
+
+	unreleased.title = "## [1.0.0] - 2024-01-02"
+	unreleased.merge(generated)
+
+	var b strings.Builder
+
+	unreleased.render(&b)
+
+	assert.Equal(t, `## [1.0.0] - 2024-01-02
+
+Some text.
+
+### Added
+
+- Manual feature
+- feature
+  - body
+
+### Changed
+
+- **BREAKING:** breaking
+- change
+
+### Security
+
+- Manual security
+
+### Custom
+
+- custom
+
+`, b.String(), "render()")
+}
+
+func Test_parseKeepDoc(t *testing.T) {
+	d, err := parseKeepDoc(strings.NewReader(`# Changelog
+
+Header text.
+
+## [Unreleased]
+
+### Added
+
+- Manual
+
+## [1.1.0] - 2024-01-02
+
+### Fixed
+
+- fix
+
+## [1.0.0] - 2024-01-01
+
+[unreleased]: https://github.com/company/project/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/company/project/compare/v1.0.0...v1.1.0
+
+[1.0.0]: https://github.com/company/project/releases/tag/v1.0.0
+`))
+	if !assert.NoError(t, err, "parseKeepDoc()") {
+		return
+	}
+
+	assert.Equal(t, []string{"# Changelog", "", "Header text."}, d.header, "header")
+	assert.Equal(t, []string{"- Manual"}, d.unreleased.items["Added"], "unreleased")
+	assert.Equal(t, "## [1.1.0] - 2024-01-02", d.sections[0], "sections")
+	assert.Equal(t, "## [1.0.0] - 2024-01-01", d.sections[len(d.sections)-1], "sections")
+	assert.Len(t, d.links, 3, "links")
+	assert.Equal(t, "1.1.0", d.prev.String(), "prev")
+}
+
+func Test_updateKeepLinks(t *testing.T) {
+	defer viper.Set(key.RemoteURL, "")
+
+	links := []keepLink{
+		{name: "unreleased", url: "https://github.com/company/project/compare/v1.0.0...HEAD"},
+		{name: "1.0.0", url: "https://github.com/company/project/releases/tag/v1.0.0"},
+	}
+
+	tag := tagTpl{tag: "1.1.0", prev: "1.0.0"}
+
+	viper.Set(key.RemoteURL, "")
+	assert.Equal(t, links, updateKeepLinks(links, tag), "updateKeepLinks() without remote URL")
+
+	viper.Set(key.RemoteURL, "https://github.com/company/project")
+	assert.Equal(t, []keepLink{
+		{name: "unreleased", url: "https://github.com/company/project/compare/v1.1.0...HEAD"},
+		{name: "1.1.0", url: "https://github.com/company/project/compare/v1.0.0...v1.1.0"},
+		{name: "1.0.0", url: "https://github.com/company/project/releases/tag/v1.0.0"},
+	}, updateKeepLinks(links, tag), "updateKeepLinks()")
+}
+
+func TestGenerator_Add_keep(t *testing.T) {
+	defer viper.Set(key.RemoteURL, "")
+
+	viper.Set(key.DryRun, false)
+	viper.Set(key.ChangelogShowAuthor, false)
+	viper.Set(key.RemoteURL, "https://github.com/company/project")
+
+	rw := __newRWMock(map[string]string{"CHANGELOG.md": `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Manual feature
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- first
+
+[unreleased]: https://github.com/company/project/compare/v1.0.0...HEAD
+[1.0.0]: https://github.com/company/project/releases/tag/v1.0.0
+`})
+
+	bcp := &__backupMock{}
+	bcp.On("Create", mock.Anything).Return(nil)
+
+	g := Generator{
+		repo: __newGitRepoMock([]git.Commit{
+			{Hash: "2222222", Message: "chore(release): 1.1.0", Version: "1.1.0", Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{Hash: "1111111", Message: "fix: fix"},
+		}, nil),
+		rw:      rw,
+		bcp:     bcp,
+		f:       "CHANGELOG.md",
+		nms:     []config.CommitName{{Type: "fix", Name: "Bug Fixes"}},
+		formats: []config.ChangelogFormat{config.FormatMarkdown},
+		style:   config.StyleKeepAChangelog,
+	}
+
+	if !assert.NoError(t, g.Add("1.1.0"), "Add()") {
+		return
+	}
+
+	assert.Equal(t, `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-01-02
+
+### Added
+
+- Manual feature
+
+### Fixed
+
+- fix ([1111111](https://github.com/company/project/commit/1111111))
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- first
+
+[unreleased]: https://github.com/company/project/compare/v1.1.0...HEAD
+[1.1.0]: https://github.com/company/project/compare/v1.0.0...v1.1.0
+[1.0.0]: https://github.com/company/project/releases/tag/v1.0.0
+`, rw.files[fsys.File("CHANGELOG.md").Path()].String(), "Add()")
+}
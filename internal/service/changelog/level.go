@@ -0,0 +1,88 @@
+package changelog
+
+import (
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/git"
+)
+
+// Level is a next version level, calculated from commits since the last tag.
+type Level struct {
+	// Type is a next version type (git.NextNone if there are no releasable commits).
+	Type git.NextType
+	// Commits is a list of commits, that drove the decision.
+	Commits []git.Commit
+}
+
+// NextLevel calculates next version level from commits since the last tag:
+//   - major for breaking changes;
+//   - level from commit types config (minor for features and patch for others by default).
+//
+// Commits with "none" level are not releasable.
+func (g Generator) NextLevel() (Level, error) {
+	commits, err := g.repo.Commits(func(args *git.CommitsArgs) {
+		args.LastOnly = true
+	})
+	if err != nil {
+		return Level{}, err
+	}
+
+	return newLevel(g.nms, commits), nil
+}
+
+// newLevel returns a new Level from commits.
+func newLevel(nms []config.CommitName, commits []git.Commit) Level {
+	l := Level{
+		Type:    git.NextNone,
+		Commits: []git.Commit{},
+	}
+
+	for _, c := range commits {
+		if c.IsTag() {
+			continue
+		}
+
+		nt := newCommitTpl(c).nextType(nms)
+		if nt == git.NextNone {
+			continue
+		}
+
+		switch {
+		case nt.Higher(l.Type):
+			l.Type = nt
+			l.Commits = []git.Commit{c}
+		case nt == l.Type:
+			l.Commits = append(l.Commits, c)
+		}
+	}
+
+	return l
+}
+
+// nextType returns the next version type, required by the commit.
+func (m commitTpl) nextType(nms []config.CommitName) git.NextType {
+	if m.isBreakingChange {
+		return git.NextMajor
+	}
+
+	// Default level for commit types, that are not in the config.
+	level := config.CommitName{Type: m.CommitType}.Level()
+
+	for _, nm := range nms {
+		if nm.Type == m.CommitType {
+			level = nm.Level()
+
+			break
+		}
+	}
+
+	switch level {
+	case config.BumpMajor:
+		return git.NextMajor
+	case config.BumpMinor:
+		return git.NextMinor
+	case config.BumpPatch:
+		return git.NextPatch
+	default:
+		return git.NextNone
+	}
+}
@@ -0,0 +1,162 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/klimby/version/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerator_NextLevel(t *testing.T) {
+	nms := []config.CommitName{
+		{Type: "feat", Name: "Features"},
+		{Type: "fix", Name: "Bug Fixes", Bump: config.BumpPatch},
+		{Type: "perf", Name: "Performance Improvements", Bump: config.BumpMinor},
+		{Type: "docs", Name: "Documentation", Bump: config.BumpNone},
+		{Type: "ci", Name: "Continuous Integration", Bump: config.BumpNone},
+	}
+
+	tests := []struct {
+		name        string
+		commits     []git.Commit
+		err         error
+		wantType    git.NextType
+		wantCommits int
+		assertion   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "major",
+			commits: []git.Commit{
+				{Message: "feat: feature"},
+				{Message: "fix!: breaking fix"},
+				{Message: "feat(scope): message\n\nBREAKING CHANGE: foo"},
+			},
+			wantType:    git.NextMajor,
+			wantCommits: 2,
+			assertion:   assert.NoError,
+		},
+		{
+			name: "minor",
+			commits: []git.Commit{
+				{Message: "fix: fix"},
+				{Message: "feat: feature"},
+				{Message: "docs: docs"},
+			},
+			wantType:    git.NextMinor,
+			wantCommits: 1,
+			assertion:   assert.NoError,
+		},
+		{
+			name: "patch",
+			commits: []git.Commit{
+				{Message: "fix: fix"},
+				{Message: "message without type"},
+			},
+			wantType:    git.NextPatch,
+			wantCommits: 2,
+			assertion:   assert.NoError,
+		},
+		{
+			name: "configured minor",
+			commits: []git.Commit{
+				{Message: "fix: fix"},
+				{Message: "perf: performance"},
+			},
+			wantType:    git.NextMinor,
+			wantCommits: 1,
+			assertion:   assert.NoError,
+		},
+		{
+			name: "no release",
+			commits: []git.Commit{
+				{Message: "docs: docs"},
+				{Message: "ci: ci"},
+			},
+			wantType:    git.NextNone,
+			wantCommits: 0,
+			assertion:   assert.NoError,
+		},
+		{
+			name: "tag only",
+			commits: []git.Commit{
+				{Message: "chore(release): 1.0.0", Version: version.V("1.0.0")},
+			},
+			wantType:    git.NextNone,
+			wantCommits: 0,
+			assertion:   assert.NoError,
+		},
+		{
+			name:      "commits error",
+			err:       assert.AnError,
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := __newGitRepoMock(tt.commits, tt.err)
+
+			g := New(func(args *Args) {
+				args.Repo = repo
+				args.CommitNames = nms
+			})
+
+			got, err := g.NextLevel()
+			if !tt.assertion(t, err, "NextLevel()") || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.wantType, got.Type, "NextLevel() type")
+			assert.Len(t, got.Commits, tt.wantCommits, "NextLevel() commits")
+		})
+	}
+}
+
+type __gitRepoMock struct {
+	mock.Mock
+}
+
+func (m *__gitRepoMock) Commits(opts ...func(options *git.CommitsArgs)) ([]git.Commit, error) {
+	a := &git.CommitsArgs{}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	ret := m.Called()
+
+	var r0 []git.Commit
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]git.Commit)
+	}
+
+	// the next version commit, if it is not in commits.
+	if !a.NextV.Empty() && (len(r0) == 0 || !r0[0].Version.Equal(a.NextV)) {
+		r0 = append([]git.Commit{{
+			Hash:    "0000000",
+			Message: "chore(release): " + a.NextV.FormatString(),
+			Version: a.NextV,
+			Date:    time.Now(),
+		}}, r0...)
+	}
+
+	return r0, ret.Error(1)
+}
+
+func (m *__gitRepoMock) Add(files ...fsys.File) error {
+	ret := m.Called(files)
+
+	return ret.Error(0)
+}
+
+func __newGitRepoMock(commits []git.Commit, err error) *__gitRepoMock {
+	m := &__gitRepoMock{}
+	m.On("Commits").Return(commits, err)
+	m.On("Add", mock.Anything).Return(nil)
+
+	return m
+}
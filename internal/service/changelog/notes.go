@@ -0,0 +1,114 @@
+package changelog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/klimby/version/pkg/version"
+)
+
+// NotesArgs is a release notes arguments.
+type NotesArgs struct {
+	// Version is a version for notes. If empty, then notes for the last version are returned.
+	Version version.V
+	// Unreleased is a flag for notes from commits since the last version.
+	Unreleased bool
+	// Next is a not created (next) version. Notes are created from commits since the last version,
+	// as for the changelog section of the next version.
+	Next version.V
+}
+
+// ReleaseNotes writes release notes (changelog section) for one version to writer.
+// Changelog file is not changed.
+func (g Generator) ReleaseNotes(wr io.Writer, opts ...func(*NotesArgs)) error {
+	a := &NotesArgs{}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	tag, err := g.notesTag(*a)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := g.sectionTemplate()
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+
+	if g.style == config.StyleKeepAChangelog {
+		s, err := newKeepSection(keepTag(g.nms, tag), tmpl)
+		if err != nil {
+			return err
+		}
+
+		s.render(&b)
+	} else if err := tag.applyTemplate(&b, tmpl); err != nil {
+		return err
+	}
+
+	notes := strings.TrimLeft(string(builder2B(b)), "\n")
+
+	if _, err := io.WriteString(wr, strings.TrimRight(notes, "\n")+"\n"); err != nil {
+		return fmt.Errorf("write release notes error: %w", err)
+	}
+
+	return nil
+}
+
+// notesTag returns a tag template for release notes.
+func (g Generator) notesTag(a NotesArgs) (tagTpl, error) {
+	c, err := g.repo.Commits(func(args *git.CommitsArgs) {
+		if !a.Next.Empty() {
+			args.NextV = a.Next
+			args.LastOnly = true
+		}
+	})
+	if err != nil {
+		return tagTpl{}, err
+	}
+
+	if a.Unreleased {
+		tag := newTagTpl(g.nms, "", time.Now())
+
+		for _, cm := range c {
+			if cm.IsTag() {
+				tag.setPrev(cm.Version)
+				break
+			}
+
+			tag.addCommit(cm)
+		}
+
+		if tag.empty() {
+			return tag, fmt.Errorf("%w: no unreleased commits", ErrWarning)
+		}
+
+		return tag, nil
+	}
+
+	tags := newTagsTpl(g.nms, c)
+
+	if len(tags.Tags) == 0 {
+		return tagTpl{}, fmt.Errorf("%w: no versions", ErrWarning)
+	}
+
+	if a.Version.Empty() || !a.Next.Empty() {
+		return tags.Tags[0], nil
+	}
+
+	for _, t := range tags.Tags {
+		if t.tag.Equal(a.Version) {
+			return t, nil
+		}
+	}
+
+	return tagTpl{}, fmt.Errorf("version %s not found", a.Version.FormatString())
+}
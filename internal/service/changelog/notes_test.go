@@ -0,0 +1,111 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/git"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_ReleaseNotes(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	commits := []git.Commit{
+		{Hash: "5555555", Message: "fix: unreleased"},
+		{Hash: "4444444", Message: "chore(release): 1.1.0", Version: "1.1.0", Date: date},
+		{Hash: "3333333", Message: "feat: second"},
+		{Hash: "2222222", Message: "chore(release): 1.0.0", Version: "1.0.0", Date: date},
+		{Hash: "1111111", Message: "feat: first"},
+	}
+
+	tests := []struct {
+		name      string
+		commits   []git.Commit
+		args      NotesArgs
+		want      string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "last",
+			commits:   commits,
+			want:      "## 1.1.0 (2024-01-02)\n\n### Features\n\n* second (3333333)\n",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "version",
+			commits:   commits,
+			args:      NotesArgs{Version: "1.0.0"},
+			want:      "## 1.0.0 (2024-01-02)\n\n### Features\n\n* first (1111111)\n",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "version not found",
+			commits:   commits,
+			args:      NotesArgs{Version: "2.0.0"},
+			assertion: assert.Error,
+		},
+		{
+			name:      "unreleased",
+			commits:   commits,
+			args:      NotesArgs{Unreleased: true},
+			want:      "## Unreleased (" + time.Now().Format("2006-01-02") + ")\n\n### Bug Fixes\n\n* unreleased (5555555)\n",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "no unreleased commits",
+			commits:   commits[1:],
+			args:      NotesArgs{Unreleased: true},
+			assertion: assert.Error,
+		},
+		{
+			name: "next",
+			commits: append([]git.Commit{
+				{Hash: "6666666", Message: "chore(release): 1.2.0", Version: "1.2.0", Date: date},
+			}, commits...),
+			args:      NotesArgs{Next: "1.2.0"},
+			want:      "## 1.2.0 (2024-01-02)\n\n### Bug Fixes\n\n* unreleased (5555555)\n",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "no versions",
+			commits:   commits[:1],
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.RemoteURL, "")
+			viper.Set(key.ChangelogShowAuthor, false)
+
+			g := Generator{
+				repo: __newGitRepoMock(tt.commits, nil),
+				nms: []config.CommitName{
+					{Type: "feat", Name: "Features"},
+					{Type: "fix", Name: "Bug Fixes"},
+				},
+			}
+
+			var b strings.Builder
+
+			err := g.ReleaseNotes(&b, func(args *NotesArgs) {
+				*args = tt.args
+			})
+			if !tt.assertion(t, err, "ReleaseNotes()") || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want, b.String(), "ReleaseNotes()")
+		})
+	}
+}
+
+func Test_tagTpl_Name(t *testing.T) {
+	assert.Equal(t, "1.2.3", tagTpl{tag: version.V("1.2.3")}.Name(), "Name()")
+	assert.Equal(t, "Unreleased", tagTpl{}.Name(), "Name() unreleased")
+}
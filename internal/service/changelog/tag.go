@@ -17,6 +17,9 @@ import (
 	"github.com/spf13/viper"
 )
 
+// _unreleasedName is a section name for commits since the last version.
+const _unreleasedName = "Unreleased"
+
 // tagTpl is a tag template.
 type tagTpl struct {
 	tag             version.V
@@ -82,19 +85,58 @@ func (t *tagTpl) addCommit(c git.Commit) {
 	}
 }
 
-// applyTemplate applies the template to the commit message.
-func (t *tagTpl) applyTemplate(wr io.Writer) error {
+// empty returns true if the tag has no commits.
+func (t tagTpl) empty() bool {
+	if len(t.BreakingChanges) > 0 {
+		return false
+	}
+
+	for _, b := range t.Blocks {
+		if len(b.Commits) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Name returns the tag version or "Unreleased" for commits since the last version.
+func (t tagTpl) Name() string {
+	if t.tag.Invalid() {
+		return _unreleasedName
+	}
+
+	return t.tag.FormatString()
+}
+
+// Version returns the tag version.
+func (t tagTpl) Version() version.V {
+	return t.tag
+}
+
+// Previous returns the previous tag version (empty for the first version).
+func (t tagTpl) Previous() version.V {
+	return t.prev
+}
+
+// newTagTemplate returns a new version section template with template functions.
+func newTagTemplate(text string) (*template.Template, error) {
 	funcMap := template.FuncMap{
 		"versionName": versionName(),
 		"commitName":  commitName(),
 		"addIssueURL": addIssueURL(),
 	}
 
-	tmpl, err := template.New("tag").Funcs(funcMap).Parse(_tagMarkdownTpl)
+	tmpl, err := template.New("tag").Funcs(funcMap).Parse(text)
 	if err != nil {
-		return fmt.Errorf("parse tag template error: %w", err)
+		return nil, fmt.Errorf("parse tag template error: %w", err)
 	}
 
+	return tmpl, nil
+}
+
+// applyTemplate applies the template to the commit message.
+func (t *tagTpl) applyTemplate(wr io.Writer, tmpl *template.Template) error {
 	if err := tmpl.Execute(wr, t); err != nil {
 		return fmt.Errorf("execute tag template error: %w", err)
 	}
@@ -107,16 +149,12 @@ func versionName() func(t tagTpl) string {
 	remoteURL := viper.GetString(key.RemoteURL)
 
 	return func(t tagTpl) string {
-		if remoteURL == "" || t.tag.Invalid() || t.prev.Invalid() {
-			return t.tag.FormatString()
-		}
-
-		u, err := url.JoinPath(remoteURL, "compare", fmt.Sprintf("%s...%s", t.prev.GitVersion(), t.tag.GitVersion()))
-		if err != nil {
-			return t.tag.FormatString()
+		u := compareURL(remoteURL, t)
+		if u == "" {
+			return t.Name()
 		}
 
-		return fmt.Sprintf("[%s](%s)", t.tag.FormatString(), u)
+		return fmt.Sprintf("[%s](%s)", t.Name(), u)
 	}
 }
 
@@ -126,10 +164,7 @@ func commitName() func(c commitTpl) string {
 	showAuthor := viper.GetBool(key.ChangelogShowAuthor)
 
 	return func(c commitTpl) string {
-		var (
-			b strings.Builder
-			u string
-		)
+		var b strings.Builder
 
 		if c.Scope != "" {
 			b.WriteString("**")
@@ -139,14 +174,7 @@ func commitName() func(c commitTpl) string {
 
 		b.WriteString(c.Message)
 
-		if remoteURL != "" {
-			ur, err := url.JoinPath(remoteURL, "commit", c.Hash)
-			if err == nil {
-				u = ur
-			}
-		}
-
-		if u != "" {
+		if u := commitURL(remoteURL, c.Hash); u != "" {
 			b.WriteString(" ([" + c.shortHash() + "](" + u + "))")
 		} else {
 			b.WriteString(" (" + c.shortHash() + ")")
@@ -195,11 +223,18 @@ type tagsTpl struct {
 }
 
 // newTagsTpl returns a new tagsTpl.
+// If changelog.foldPrerelease is set, then pre-release commits are added to the release section.
 func newTagsTpl(nms []config.CommitName, commits []git.Commit) tagsTpl {
 	var tags []tagTpl
 
+	fold := viper.GetBool(key.ChangelogFoldPrerelease)
+
 	for _, c := range commits {
 		if c.IsTag() {
+			if fold && len(tags) > 0 && isFolded(tags[len(tags)-1].tag, c.Version) {
+				continue
+			}
+
 			if len(tags) > 0 {
 				tags[len(tags)-1].setPrev(c.Version)
 			}
@@ -225,10 +260,15 @@ func newTagsTpl(nms []config.CommitName, commits []git.Commit) tagsTpl {
 	}
 }
 
+// isFolded returns true if the pre-release version pre is folded into the release version.
+func isFolded(release, pre version.V) bool {
+	return !release.IsPrerelease() && pre.IsPrerelease() && release.Release().Equal(pre.Release())
+}
+
 // applyTemplate applies the template to the commit message.
-func (t tagsTpl) applyTemplate(wr io.Writer) error {
+func (t tagsTpl) applyTemplate(wr io.Writer, tmpl *template.Template) error {
 	for _, t := range t.Tags {
-		if err := t.applyTemplate(wr); err != nil {
+		if err := t.applyTemplate(wr, tmpl); err != nil {
 			return err
 		}
 	}
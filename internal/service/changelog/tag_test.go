@@ -113,10 +113,11 @@ func Test_commitName(t *testing.T) {
 
 func Test_versionName(t *testing.T) {
 	tests := []struct {
-		name      string
-		remoteUrl string
-		t         tagTpl
-		want      string
+		name        string
+		remoteUrl   string
+		tagTemplate string
+		t           tagTpl
+		want        string
 	}{
 		{
 			name:      "version",
@@ -127,6 +128,16 @@ func Test_versionName(t *testing.T) {
 			},
 			want: "[1.0.0](https://example.com/compare/v0.1.0...v1.0.0)",
 		},
+		{
+			name:        "tag template",
+			remoteUrl:   "https://example.com",
+			tagTemplate: "release-{{.Version}}",
+			t: tagTpl{
+				tag:  "1.0.0",
+				prev: "0.1.0",
+			},
+			want: "[1.0.0](https://example.com/compare/release-0.1.0...release-1.0.0)",
+		},
 		{
 			name: "empty remote url",
 			t: tagTpl{
@@ -148,6 +159,8 @@ func Test_versionName(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			viper.Set(key.RemoteURL, tt.remoteUrl)
+			viper.Set(key.GitTagTemplate, tt.tagTemplate)
+			defer viper.Set(key.GitTagTemplate, "")
 
 			assert.Equal(t, tt.want, versionName()(tt.t))
 		})
@@ -349,7 +362,10 @@ func Test_tagTpl_applyTemplate(t1 *testing.T) {
 
 			wr := &bytes.Buffer{}
 
-			err := t.applyTemplate(wr)
+			tmpl, err := newTagTemplate(_tagMarkdownTpl)
+			assert.NoError(t1, err, "newTagTemplate()")
+
+			err = t.applyTemplate(wr, tmpl)
 
 			if !tt.wantErr(t1, err, fmt.Sprintf("applyTemplate(%v)", wr)) {
 				return
@@ -411,6 +427,74 @@ func Test_newTagsTpl(t *testing.T) {
 
 }
 
+func Test_newTagsTplFoldPrerelease(t *testing.T) {
+	nms := []config.CommitName{
+		{Type: "feat", Name: "Features"},
+	}
+
+	commits := []git.Commit{
+		{
+			Message: "chore(release): 1.1.0",
+			Version: version.V("v1.1.0"),
+		},
+		{
+			Message: "feat: message 2",
+		},
+		{
+			Message: "chore(release): 1.1.0-rc.1",
+			Version: version.V("v1.1.0-rc.1"),
+		},
+		{
+			Message: "feat: message 1",
+		},
+		{
+			Message: "chore(release): 1.0.0",
+			Version: version.V("v1.0.0"),
+		},
+		{
+			Message: "feat: message 0",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fold     bool
+		wantTags int
+		wantPrev version.V
+		wantLen  int
+	}{
+		{
+			name:     "fold",
+			fold:     true,
+			wantTags: 2,
+			wantPrev: version.V("v1.0.0"),
+			wantLen:  2,
+		},
+		{
+			name:     "no fold",
+			fold:     false,
+			wantTags: 3,
+			wantPrev: version.V("v1.1.0-rc.1"),
+			wantLen:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.ChangelogFoldPrerelease, tt.fold)
+
+			t.Cleanup(func() {
+				viper.Set(key.ChangelogFoldPrerelease, false)
+			})
+
+			tTpl := newTagsTpl(nms, commits)
+
+			assert.Len(t, tTpl.Tags, tt.wantTags, "tags length")
+			assert.Equal(t, tt.wantPrev, tTpl.Tags[0].prev, "release prev")
+			assert.Len(t, tTpl.Tags[0].Blocks[0].Commits, tt.wantLen, "release commits length")
+		})
+	}
+}
+
 func Test_tagsTpl_applyTemplate(t *testing.T) {
 	nms := []config.CommitName{
 		{Type: "feat", Name: "Features"},
@@ -433,7 +517,63 @@ func Test_tagsTpl_applyTemplate(t *testing.T) {
 	viper.Set(key.RemoteURL, "https://example.com")
 	viper.Set(key.ChangelogIssueURL, "https://example.com/issues/")
 
-	err := tTpl.applyTemplate(&bytes.Buffer{})
+	tmpl, err := newTagTemplate(_tagMarkdownTpl)
+	assert.NoError(t, err, "newTagTemplate()")
+
+	err = tTpl.applyTemplate(&bytes.Buffer{}, tmpl)
 
 	assert.NoError(t, err, "applyTemplate")
 }
+
+func Test_newTagTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		want      string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "custom template",
+			text:      `{{ .Version.FormatString }} after {{ .Previous.FormatString }}{{ range .Blocks }}: {{ .Name }}{{ range .Commits }} {{ commitName . }}{{ end }}{{ end }}`,
+			want:      "1.0.0 after 0.1.0: Features message (0123456)",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "parse error",
+			text:      `{{ .Version`,
+			assertion: assert.Error,
+		},
+		{
+			name:      "unknown function",
+			text:      `{{ unknown . }}`,
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.RemoteURL, "")
+
+			tmpl, err := newTagTemplate(tt.text)
+			if !tt.assertion(t, err, "newTagTemplate()") || err != nil {
+				return
+			}
+
+			tag := &tagTpl{
+				tag:  "1.0.0",
+				prev: "0.1.0",
+				Blocks: []tagTplBlock{
+					{
+						CommitType: "feat",
+						Name:       "Features",
+						Commits:    []commitTpl{{Message: "message", Hash: "0123456789"}},
+					},
+				},
+			}
+
+			var b bytes.Buffer
+
+			assert.NoError(t, tag.applyTemplate(&b, tmpl), "applyTemplate()")
+			assert.Equal(t, tt.want, b.String(), "applyTemplate()")
+		})
+	}
+}
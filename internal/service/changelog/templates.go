@@ -1,5 +1,37 @@
 package changelog
 
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/klimby/version/internal/config"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/console"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/spf13/viper"
+)
+
+// Default template files for dump.
+const (
+	DefaultTemplateFile       = "changelog.tpl"
+	DefaultHeaderTemplateFile = "changelog-header.tpl"
+)
+
+// headerTpl is a header template data.
+type headerTpl struct {
+	// Title is a changelog title.
+	Title string
+}
+
+// _headerMarkdownTpl is a default changelog header template.
+const _headerMarkdownTpl = `# {{ .Title }}
+
+All notable changes to this project will be documented in this file. See [Conventional CommitsFromLast](https://www.conventionalcommits.org/en/v1.0.0/) for commit guidelines.
+`
+
+// _tagMarkdownTpl is a default changelog version section template.
 const _tagMarkdownTpl = `
 ## {{ versionName . }} ({{.Date}})
 {{- if .BreakingChanges}}
@@ -27,3 +59,150 @@ const _tagMarkdownTpl = `
 {{- end -}}
 {{- end}}
 `
+
+// _headerKeepTpl is a default changelog header template for keepachangelog style.
+const _headerKeepTpl = `# {{ .Title }}
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// _tagKeepTpl is a default changelog version section template for keepachangelog style.
+// Blocks are Keep a Changelog categories, compare links are added to the changelog footer.
+const _tagKeepTpl = `
+## [{{ .Name }}]{{ if .Version }} - {{ .Date }}{{ end }}
+{{- if .BreakingChanges}}
+
+### Changed
+{{ range .BreakingChanges}}
+- **BREAKING:** {{ commitName . }}
+{{- range .Body}}
+  - {{addIssueURL .}}
+{{- end}}
+{{- end}}
+{{- end -}}
+
+{{- range .Blocks}}
+{{- if .Commits }}
+
+### {{ .Name }}
+{{ range .Commits}}
+- {{ commitName . }}
+{{- range .Body}}
+  - {{addIssueURL .}}
+{{- end}}
+{{- end}}
+{{- end -}}
+{{- end}}
+`
+
+// defaultTemplates returns default version section and header templates for the changelog style.
+func (g Generator) defaultTemplates() (tpl, header string) {
+	if g.style == config.StyleKeepAChangelog {
+		return _tagKeepTpl, _headerKeepTpl
+	}
+
+	return _tagMarkdownTpl, _headerMarkdownTpl
+}
+
+// sectionTemplate returns the version section template from file or default template for the changelog style.
+func (g Generator) sectionTemplate() (*template.Template, error) {
+	def, _ := g.defaultTemplates()
+
+	text, err := g.readTemplate(g.tpl, def)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTagTemplate(text)
+}
+
+// readTemplate returns the template file content or default template, if the file is not set.
+func (g Generator) readTemplate(f fsys.File, def string) (_ string, err error) {
+	if f.Empty() {
+		return def, nil
+	}
+
+	r, err := g.rw.Read(f.Path())
+	if err != nil {
+		return "", fmt.Errorf("open template file %s error: %w", f.String(), err)
+	}
+
+	defer func() {
+		if e := r.Close(); e != nil {
+			if err == nil {
+				err = fmt.Errorf("close template file %s error: %w", f.String(), e)
+			}
+		}
+	}()
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("read template file %s error: %w", f.String(), err)
+	}
+
+	return string(b), nil
+}
+
+// GenerateTemplates writes default templates to template files for customization.
+// If template files are not set, then DefaultTemplateFile and DefaultHeaderTemplateFile are used.
+func (g Generator) GenerateTemplates() error {
+	tpl, headerTpl := g.tpl, g.headerTpl
+
+	if tpl.Empty() {
+		tpl = DefaultTemplateFile
+	}
+
+	if headerTpl.Empty() {
+		headerTpl = DefaultHeaderTemplateFile
+	}
+
+	defTpl, defHeader := g.defaultTemplates()
+
+	if err := g.writeTemplate(tpl, defTpl); err != nil {
+		return err
+	}
+
+	if err := g.writeTemplate(headerTpl, defHeader); err != nil {
+		return err
+	}
+
+	viper.Set(key.ChangelogTemplate, tpl.String())
+	viper.Set(key.ChangelogHeaderTemplate, headerTpl.String())
+
+	return nil
+}
+
+// writeTemplate writes template to file.
+func (g Generator) writeTemplate(f fsys.File, text string) (err error) {
+	if err := g.bcp.Create(f.Path()); err != nil {
+		return err
+	}
+
+	if viper.GetBool(key.DryRun) {
+		return nil
+	}
+
+	w, err := g.rw.Write(f.Path(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	if err != nil {
+		return fmt.Errorf("open template file %s error: %w", f.String(), err)
+	}
+
+	defer func() {
+		if e := w.Close(); e != nil {
+			if err == nil {
+				err = fmt.Errorf("close template file %s error: %w", f.String(), e)
+			}
+		}
+	}()
+
+	if _, err := io.WriteString(w, text); err != nil {
+		return fmt.Errorf("write template file %s error: %w", f.String(), err)
+	}
+
+	console.Success(fmt.Sprintf("Template %s created.", f.String()))
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package changelog
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/fsys"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerator_readTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		f         fsys.File
+		files     map[string]string
+		want      string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "default",
+			want:      _tagMarkdownTpl,
+			assertion: assert.NoError,
+		},
+		{
+			name:      "file",
+			f:         "changelog.tpl",
+			files:     map[string]string{"changelog.tpl": "## {{ .Version }}"},
+			want:      "## {{ .Version }}",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "file not found",
+			f:         "changelog.tpl",
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Generator{rw: __newRWMock(tt.files)}
+
+			got, err := g.readTemplate(tt.f, _tagMarkdownTpl)
+			if !tt.assertion(t, err, "readTemplate()") || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want, got, "readTemplate()")
+		})
+	}
+}
+
+func TestGenerator_applyHeaderTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		headerTpl fsys.File
+		files     map[string]string
+		want      string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "default",
+			want:      "# Changelog\n\nAll notable changes to this project will be documented in this file.",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "custom",
+			headerTpl: "header.tpl",
+			files:     map[string]string{"header.tpl": "# {{ .Title }} of project\n"},
+			want:      "# Changelog of project\n",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "parse error",
+			headerTpl: "header.tpl",
+			files:     map[string]string{"header.tpl": "# {{ .Title "},
+			assertion: assert.Error,
+		},
+		{
+			name:      "execute error",
+			headerTpl: "header.tpl",
+			files:     map[string]string{"header.tpl": "# {{ .Unknown }}"},
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set(key.ChangelogTitle, "Changelog")
+
+			g := Generator{
+				rw:        __newRWMock(tt.files),
+				headerTpl: tt.headerTpl,
+			}
+
+			var b strings.Builder
+
+			err := g.applyHeaderTemplate(&b)
+			if !tt.assertion(t, err, "applyHeaderTemplate()") || err != nil {
+				return
+			}
+
+			assert.True(t, strings.HasPrefix(b.String(), tt.want), "applyHeaderTemplate() = %s", b.String())
+		})
+	}
+}
+
+func TestGenerator_GenerateTemplates(t *testing.T) {
+	tests := []struct {
+		name       string
+		tpl        fsys.File
+		headerTpl  fsys.File
+		wantTpl    string
+		wantHeader string
+	}{
+		{
+			name:       "default files",
+			wantTpl:    DefaultTemplateFile,
+			wantHeader: DefaultHeaderTemplateFile,
+		},
+		{
+			name:       "configured files",
+			tpl:        "tpl/section.tpl",
+			headerTpl:  "tpl/header.tpl",
+			wantTpl:    "tpl/section.tpl",
+			wantHeader: "tpl/header.tpl",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				viper.Set(key.ChangelogTemplate, "")
+				viper.Set(key.ChangelogHeaderTemplate, "")
+			}()
+
+			rw := __newRWMock(nil)
+			bcp := &__backupMock{}
+			bcp.On("Create", mock.Anything).Return(nil)
+
+			g := Generator{
+				rw:        rw,
+				bcp:       bcp,
+				tpl:       tt.tpl,
+				headerTpl: tt.headerTpl,
+			}
+
+			assert.NoError(t, g.GenerateTemplates(), "GenerateTemplates()")
+
+			assert.Equal(t, _tagMarkdownTpl, rw.files[fsys.File(tt.wantTpl).Path()].String(), "template content")
+			assert.Equal(t, _headerMarkdownTpl, rw.files[fsys.File(tt.wantHeader).Path()].String(), "header template content")
+
+			assert.Equal(t, tt.wantTpl, viper.GetString(key.ChangelogTemplate), "template key")
+			assert.Equal(t, tt.wantHeader, viper.GetString(key.ChangelogHeaderTemplate), "header template key")
+		})
+	}
+}
+
+// __rwMock is an in-memory file reader and writer.
+type __rwMock struct {
+	files map[string]*bytes.Buffer
+}
+
+func __newRWMock(files map[string]string) *__rwMock {
+	m := &__rwMock{files: map[string]*bytes.Buffer{}}
+
+	for p, s := range files {
+		m.files[fsys.File(p).Path()] = bytes.NewBufferString(s)
+	}
+
+	return m
+}
+
+func (m *__rwMock) Read(p string) (io.ReadCloser, error) {
+	b, ok := m.files[p]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+
+	return io.NopCloser(bytes.NewReader(b.Bytes())), nil
+}
+
+func (m *__rwMock) Write(p string, _ int) (io.WriteCloser, error) {
+	b := &bytes.Buffer{}
+	m.files[p] = b
+
+	return __nopWriteCloser{b}, nil
+}
+
+type __nopWriteCloser struct {
+	io.Writer
+}
+
+func (__nopWriteCloser) Close() error {
+	return nil
+}
+
+type __backupMock struct {
+	mock.Mock
+}
+
+func (m *__backupMock) Create(path string) error {
+	ret := m.Called(path)
+
+	return ret.Error(0)
+}
@@ -0,0 +1,268 @@
+package fsys
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+const (
+	// _globAny is a pattern segment, that matches zero or more directories.
+	_globAny = "**"
+	// _gitDir is a git repository directory (or file for worktrees and submodules).
+	_gitDir = ".git"
+	// _gitignoreFile is a file with ignore patterns of the directory.
+	_gitignoreFile = ".gitignore"
+)
+
+// IsGlob returns true if the path contains glob meta characters (*, ? or [).
+func IsGlob(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// Match reports whether slash-separated name matches the pattern.
+// Pattern syntax is the same as in path.Match, "**" segment matches zero or more directories.
+func Match(pattern, name string) (bool, error) {
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+
+	for _, s := range segments {
+		if _, err := path.Match(s, ""); err != nil {
+			return false, err
+		}
+	}
+
+	return matchSegments(segments, strings.Split(filepath.ToSlash(name), "/")), nil
+}
+
+// matchSegments reports whether name segments match pattern segments.
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == _globAny {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], name[1:])
+}
+
+// glob returns sorted paths of the files, that match the pattern.
+// Search starts from the longest directory prefix of the pattern without meta characters, .git directories are skipped.
+// If the search root is inside a git working tree, files and directories ignored by git
+// (.gitignore files and .git/info/exclude) are skipped too.
+func glob(pattern string) ([]string, error) {
+	pattern = filepath.Clean(pattern)
+	segments := strings.Split(pattern, string(filepath.Separator))
+
+	i := 0
+	for i < len(segments) && !IsGlob(segments[i]) {
+		i++
+	}
+
+	root := strings.Join(segments[:i], string(filepath.Separator))
+	switch {
+	case root == "" && filepath.IsAbs(pattern):
+		root = string(filepath.Separator)
+	case root == "":
+		root = "."
+	}
+
+	rest := filepath.ToSlash(filepath.Join(segments[i:]...))
+
+	if _, err := Match(rest, ""); err != nil {
+		return nil, err
+	}
+
+	ign, err := newIgnorer(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == _gitDir {
+				return filepath.SkipDir
+			}
+
+			if rel != "." && ign.ignored(rel, true) {
+				return filepath.SkipDir
+			}
+
+			return ign.load(rel)
+		}
+
+		if ign.ignored(rel, false) {
+			return nil
+		}
+
+		if ok, _ := Match(rest, rel); ok {
+			matches = append(matches, p)
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// ignorer checks paths of the walk root against the gitignore patterns of the working tree.
+// Nil ignorer (the root is not in a working tree) ignores nothing.
+type ignorer struct {
+	worktree string
+	// prefix is a path of the walk root, relative to the working tree.
+	prefix   []string
+	patterns []gitignore.Pattern
+}
+
+// newIgnorer returns ignorer for the walk root with patterns of .git/info/exclude
+// and .gitignore files of the parent directories, or nil, if the root is not in a git working tree.
+func newIgnorer(root string) (*ignorer, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	worktree := abs
+
+	for {
+		if _, err := os.Stat(filepath.Join(worktree, _gitDir)); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(worktree)
+		if parent == worktree {
+			return nil, nil
+		}
+
+		worktree = parent
+	}
+
+	rel, err := filepath.Rel(worktree, abs)
+	if err != nil {
+		return nil, err
+	}
+
+	ign := &ignorer{worktree: worktree, prefix: splitPath(rel)}
+
+	ps, err := readIgnorePatterns(filepath.Join(worktree, _gitDir, "info", "exclude"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ign.patterns = ps
+
+	for i := 0; i < len(ign.prefix); i++ {
+		if err := ign.loadDomain(ign.prefix[:i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return ign, nil
+}
+
+// ignored reports whether the path, relative to the walk root, is ignored by git.
+func (ign *ignorer) ignored(rel string, isDir bool) bool {
+	if ign == nil {
+		return false
+	}
+
+	return gitignore.NewMatcher(ign.patterns).Match(ign.domain(rel), isDir)
+}
+
+// load adds patterns of the .gitignore file in the directory, relative to the walk root.
+func (ign *ignorer) load(rel string) error {
+	if ign == nil {
+		return nil
+	}
+
+	return ign.loadDomain(ign.domain(rel))
+}
+
+// loadDomain adds patterns of the .gitignore file in the directory, relative to the working tree.
+func (ign *ignorer) loadDomain(domain []string) error {
+	p := filepath.Join(ign.worktree, filepath.Join(domain...), _gitignoreFile)
+
+	ps, err := readIgnorePatterns(p, domain)
+	if err != nil {
+		return err
+	}
+
+	ign.patterns = append(ign.patterns, ps...)
+
+	return nil
+}
+
+// domain returns path segments, relative to the working tree, for the path, relative to the walk root.
+func (ign *ignorer) domain(rel string) []string {
+	return append(append([]string{}, ign.prefix...), splitPath(rel)...)
+}
+
+// readIgnorePatterns reads gitignore patterns from the file. Not existing file has no patterns.
+func readIgnorePatterns(file string, domain []string) ([]gitignore.Pattern, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var ps []gitignore.Pattern
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		s := scanner.Text()
+		if !strings.HasPrefix(s, "#") && strings.TrimSpace(s) != "" {
+			ps = append(ps, gitignore.ParsePattern(s, domain))
+		}
+	}
+
+	return ps, scanner.Err()
+}
+
+// splitPath splits the relative path to segments. Current directory has no segments.
+func splitPath(rel string) []string {
+	if rel == "." || rel == "" {
+		return nil
+	}
+
+	return strings.Split(filepath.ToSlash(rel), "/")
+}
@@ -0,0 +1,115 @@
+package fsys
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		file      string
+		want      bool
+		assertion assert.ErrorAssertionFunc
+	}{
+		{name: "star", pattern: "packages/*/package.json", file: "packages/a/package.json", want: true, assertion: assert.NoError},
+		{name: "star one level", pattern: "packages/*/package.json", file: "packages/a/b/package.json", want: false, assertion: assert.NoError},
+		{name: "double star root", pattern: "**/Chart.yaml", file: "Chart.yaml", want: true, assertion: assert.NoError},
+		{name: "double star nested", pattern: "**/Chart.yaml", file: "charts/app/Chart.yaml", want: true, assertion: assert.NoError},
+		{name: "double star middle", pattern: "a/**/b.txt", file: "a/x/y/b.txt", want: true, assertion: assert.NoError},
+		{name: "double star tail", pattern: "a/**", file: "a/x/b.txt", want: true, assertion: assert.NoError},
+		{name: "no match", pattern: "**/Chart.yaml", file: "charts/values.yaml", want: false, assertion: assert.NoError},
+		{name: "bad pattern", pattern: "[a-", file: "a", want: false, assertion: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Match(tt.pattern, tt.file)
+			tt.assertion(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsGlob(t *testing.T) {
+	assert.True(t, IsGlob("packages/*/package.json"))
+	assert.True(t, IsGlob("file?.txt"))
+	assert.True(t, IsGlob("[ab].txt"))
+	assert.False(t, IsGlob("packages/a/package.json"))
+}
+
+func TestFS_Glob(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, f := range []string{
+		"package.json",
+		"packages/a/package.json",
+		"packages/b/package.json",
+		"packages/b/lib/package.json",
+		".git/package.json",
+	} {
+		p := filepath.Join(dir, f)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		assert.NoError(t, os.WriteFile(p, []byte("{}"), 0o600))
+	}
+
+	fs := New()
+
+	got, err := fs.Glob(filepath.Join(dir, "packages", "*", "package.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "packages", "a", "package.json"),
+		filepath.Join(dir, "packages", "b", "package.json"),
+	}, got)
+
+	got, err = fs.Glob(filepath.Join(dir, "**", "package.json"))
+	assert.NoError(t, err)
+	assert.Len(t, got, 4, ".git is skipped")
+
+	got, err = fs.Glob(filepath.Join(dir, "missing", "*.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = fs.Glob(filepath.Join(dir, "[a-"))
+	assert.Error(t, err)
+}
+
+func TestFS_Glob_gitignore(t *testing.T) {
+	dir := t.TempDir()
+
+	for f, data := range map[string]string{
+		".git/HEAD":                              "ref: refs/heads/main",
+		".git/info/exclude":                      "# local\nlocal/\n",
+		".gitignore":                             "node_modules/\n",
+		"package.json":                           "{}",
+		"node_modules/x/package.json":            "{}",
+		"local/package.json":                     "{}",
+		"packages/a/package.json":                "{}",
+		"packages/a/.gitignore":                  "dist\n!keep/dist\n",
+		"packages/a/dist/package.json":           "{}",
+		"packages/a/keep/dist/package.json":      "{}",
+		"packages/a/node_modules/y/package.json": "{}",
+	} {
+		p := filepath.Join(dir, f)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		assert.NoError(t, os.WriteFile(p, []byte(data), 0o600))
+	}
+
+	fs := New()
+
+	got, err := fs.Glob(filepath.Join(dir, "**", "package.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "package.json"),
+		filepath.Join(dir, "packages", "a", "keep", "dist", "package.json"),
+		filepath.Join(dir, "packages", "a", "package.json"),
+	}, got)
+
+	got, err = fs.Glob(filepath.Join(dir, "packages", "a", "*", "package.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, got, "parent .gitignore files are applied")
+}
@@ -0,0 +1,162 @@
+package fsys
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+// Journal is a file system wrapper, that records original content of every changed file for rollback.
+type Journal struct {
+	rw      journalRW
+	entries []journalEntry
+}
+
+// journalRW is a file system for Journal.
+type journalRW interface {
+	Read(p string) (io.ReadCloser, error)
+	Write(p string, flag int) (io.WriteCloser, error)
+	RemoveAll(p string) error
+	Exists(p string) bool
+	Glob(pattern string) ([]string, error)
+}
+
+// journalEntry is an original state of the file.
+type journalEntry struct {
+	path   string
+	exists bool
+	data   []byte
+}
+
+// JournalArgs is a Journal arguments.
+type JournalArgs struct {
+	RW journalRW
+}
+
+// NewJournal returns a new Journal.
+func NewJournal(args ...func(arg *JournalArgs)) *Journal {
+	a := &JournalArgs{
+		RW: New(),
+	}
+
+	for _, arg := range args {
+		arg(a)
+	}
+
+	return &Journal{
+		rw: a.RW,
+	}
+}
+
+// Write records original content of the file and returns a file for writing.
+func (j *Journal) Write(p string, flag int) (io.WriteCloser, error) {
+	if err := j.record(p); err != nil {
+		return nil, err
+	}
+
+	return j.rw.Write(p, flag)
+}
+
+// Read returns a file for reading.
+func (j *Journal) Read(p string) (io.ReadCloser, error) {
+	return j.rw.Read(p)
+}
+
+// RemoveAll records original content of the file and removes it.
+func (j *Journal) RemoveAll(p string) error {
+	if err := j.record(p); err != nil {
+		return err
+	}
+
+	return j.rw.RemoveAll(p)
+}
+
+// Exists returns true if the file exists.
+func (j *Journal) Exists(p string) bool {
+	return j.rw.Exists(p)
+}
+
+// Glob returns sorted paths of the files, that match the pattern.
+func (j *Journal) Glob(pattern string) ([]string, error) {
+	return j.rw.Glob(pattern)
+}
+
+// Changed returns true, if some files are recorded.
+func (j *Journal) Changed() bool {
+	return len(j.entries) > 0
+}
+
+// Rollback restores original content of the recorded files (in reverse order) and clears the journal.
+// Files, that did not exist, are removed.
+func (j *Journal) Rollback() error {
+	var errs []error
+
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		e := j.entries[i]
+
+		if err := j.restore(e); err != nil {
+			errs = append(errs, fmt.Errorf("restore file %s error: %w", e.path, err))
+		}
+	}
+
+	j.entries = nil
+
+	return errors.Join(errs...)
+}
+
+// record saves original content of the file, if it is not recorded yet.
+func (j *Journal) record(p string) (err error) {
+	if slices.ContainsFunc(j.entries, func(e journalEntry) bool { return e.path == p }) {
+		return nil
+	}
+
+	if !j.rw.Exists(p) {
+		j.entries = append(j.entries, journalEntry{path: p})
+
+		return nil
+	}
+
+	r, err := j.rw.Read(p)
+	if err != nil {
+		return fmt.Errorf("journal file %s error: %w", p, err)
+	}
+
+	defer func() {
+		if e := r.Close(); e != nil && err == nil {
+			err = fmt.Errorf("journal file %s error: %w", p, e)
+		}
+	}()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("journal file %s error: %w", p, err)
+	}
+
+	j.entries = append(j.entries, journalEntry{path: p, exists: true, data: data})
+
+	return nil
+}
+
+// restore restores original state of the file.
+func (j *Journal) restore(entry journalEntry) (err error) {
+	if !entry.exists {
+		return j.rw.RemoveAll(entry.path)
+	}
+
+	w, err := j.rw.Write(entry.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := w.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	_, err = w.Write(entry.data)
+
+	return err
+}
@@ -0,0 +1,66 @@
+package fsys
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournal_Rollback(t *testing.T) {
+	dir := t.TempDir()
+	changed := filepath.Join(dir, "changed.txt")
+	created := filepath.Join(dir, "created.txt")
+	removed := filepath.Join(dir, "removed.txt")
+
+	assert.NoError(t, os.WriteFile(changed, []byte("original\r\n"), 0o600))
+	assert.NoError(t, os.WriteFile(removed, []byte("removed"), 0o600))
+
+	j := NewJournal()
+	assert.False(t, j.Changed(), "empty journal")
+
+	// Changed twice: the first original content is restored.
+	for _, s := range []string{"first", "second"} {
+		w, err := j.Write(changed, os.O_WRONLY|os.O_TRUNC)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(s))
+		assert.NoError(t, err)
+		assert.NoError(t, w.Close())
+	}
+
+	w, err := j.Write(created, os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, j.RemoveAll(removed))
+	assert.True(t, j.Changed(), "journal with files")
+
+	assert.NoError(t, j.Rollback())
+	assert.False(t, j.Changed(), "journal is cleared")
+
+	data, err := os.ReadFile(changed)
+	assert.NoError(t, err)
+	assert.Equal(t, "original\r\n", string(data), "changed file is restored")
+
+	_, err = os.Stat(created)
+	assert.True(t, os.IsNotExist(err), "created file is removed")
+
+	data, err = os.ReadFile(removed)
+	assert.NoError(t, err)
+	assert.Equal(t, "removed", string(data), "removed file is restored")
+}
+
+func TestJournal_WriteReadError(t *testing.T) {
+	j := NewJournal(func(arg *JournalArgs) {
+		arg.RW = New(func(o *Option) {
+			o.Exists = func(string) bool { return true }
+			o.Read = func(p string) (io.ReadCloser, error) { return nil, os.ErrPermission }
+		})
+	})
+
+	_, err := j.Write("file", os.O_WRONLY)
+	assert.ErrorIs(t, err, os.ErrPermission, "file is not written, if it can not be journaled")
+	assert.False(t, j.Changed())
+}
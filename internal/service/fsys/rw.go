@@ -12,6 +12,7 @@ type FS struct {
 	read   func(string) (io.ReadCloser, error)
 	remove func(string) error
 	exists func(string) bool
+	glob   func(string) ([]string, error)
 }
 
 // Option is a file system option.
@@ -20,6 +21,7 @@ type Option struct {
 	Read   func(string) (io.ReadCloser, error)
 	Remove func(string) error
 	Exists func(string) bool
+	Glob   func(string) ([]string, error)
 }
 
 // New returns a new file system.
@@ -39,6 +41,7 @@ func New(opts ...func(*Option)) *FS {
 
 			return err == nil
 		},
+		Glob: glob,
 	}
 
 	for _, opt := range opts {
@@ -50,6 +53,7 @@ func New(opts ...func(*Option)) *FS {
 		read:   o.Read,
 		remove: o.Remove,
 		exists: o.Exists,
+		glob:   o.Glob,
 	}
 }
 
@@ -75,3 +79,8 @@ func (f FS) RemoveAll(p string) error {
 func (f FS) Exists(p string) bool {
 	return f.exists(p)
 }
+
+// Glob returns sorted paths of the files, that match the pattern ("**" matches zero or more directories).
+func (f FS) Glob(pattern string) ([]string, error) {
+	return f.glob(pattern)
+}
@@ -0,0 +1,121 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+)
+
+// Default release commit and tag message templates.
+const (
+	DefaultCommitMessage = "chore(release): {{.Version}}"
+	DefaultTagMessage    = "chore(release): {{.Version}}\n\n{{.Notes}}"
+)
+
+// _messageDateFormat is a date format in release messages.
+const _messageDateFormat = "2006-01-02"
+
+// MessageData is a data for the release commit and tag message templates.
+type MessageData struct {
+	// Version is a release version: 1.2.0.
+	Version string
+	// Previous is a previous version: 1.1.0. Empty for the first version.
+	Previous string
+	// Tag is a release tag name: v1.2.0.
+	Tag string
+	// Date is a release date: 2024-01-31.
+	Date string
+	// Notes is a release notes section (changelog section) of the version.
+	Notes string
+}
+
+// ValidateMessage validates the release message template.
+func ValidateMessage(tpl string) error {
+	_, err := renderMessage(tpl, MessageData{
+		Version:  "1.2.0",
+		Previous: "1.1.0",
+		Tag:      "v1.2.0",
+		Date:     "2024-01-31",
+		Notes:    "### Features",
+	})
+
+	return err
+}
+
+// commitMessageTpl returns the release commit message template (git.commitMessage).
+func commitMessageTpl() string {
+	if s := viper.GetString(key.GitCommitMessage); s != "" {
+		return s
+	}
+
+	return DefaultCommitMessage
+}
+
+// tagMessageTpl returns the release tag message template (git.tagMessage).
+func tagMessageTpl() string {
+	if s := viper.GetString(key.GitTagMessage); s != "" {
+		return s
+	}
+
+	return DefaultTagMessage
+}
+
+// messageData returns data for the release messages.
+// Tag is the tag name by the format, previous version is the last version tag (except v) by the format.
+func (r Repository) messageData(v version.V, f TagFormat, date time.Time, notes string) (MessageData, error) {
+	d := MessageData{
+		Version: v.FormatString(),
+		Tag:     f.Name(v),
+		Date:    date.Format(_messageDateFormat),
+		Notes:   strings.TrimSpace(notes),
+	}
+
+	tags, err := r.tags(f)
+	if err != nil {
+		return d, err
+	}
+
+	for i := len(tags) - 1; i >= 0; i-- {
+		if !tags[i].ver.Equal(v) {
+			d.Previous = tags[i].ver.FormatString()
+
+			break
+		}
+	}
+
+	return d, nil
+}
+
+// renderMessage returns the release message by template. Leading and trailing spaces are removed.
+func renderMessage(tpl string, d MessageData) (string, error) {
+	t, err := template.New("message").Option("missingkey=error").Parse(tpl)
+	if err != nil {
+		return "", fmt.Errorf("parse message template %q error: %w", tpl, err)
+	}
+
+	var b strings.Builder
+
+	if err := t.Execute(&b, d); err != nil {
+		return "", fmt.Errorf("execute message template %q error: %w", tpl, err)
+	}
+
+	msg := strings.TrimSpace(b.String())
+	if msg == "" {
+		return "", errors.New("release message is empty")
+	}
+
+	return msg, nil
+}
+
+// subject returns the first line of the message.
+func subject(msg string) string {
+	s, _, _ := strings.Cut(msg, "\n")
+
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,121 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_renderMessage(t *testing.T) {
+	d := MessageData{
+		Version:  "1.2.0",
+		Previous: "1.1.0",
+		Tag:      "v1.2.0",
+		Date:     "2024-01-31",
+		Notes:    "### Features\n\n* feature",
+	}
+
+	tests := []struct {
+		name      string
+		tpl       string
+		want      string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "default commit",
+			tpl:       DefaultCommitMessage,
+			want:      "chore(release): 1.2.0",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "default tag",
+			tpl:       DefaultTagMessage,
+			want:      "chore(release): 1.2.0\n\n### Features\n\n* feature",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "all variables",
+			tpl:       "release {{.Tag}} ({{.Previous}} -> {{.Version}}) {{.Date}}",
+			want:      "release v1.2.0 (1.1.0 -> 1.2.0) 2024-01-31",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "parse error",
+			tpl:       "release {{.Version",
+			assertion: assert.Error,
+		},
+		{
+			name:      "unknown variable",
+			tpl:       "release {{.Unknown}}",
+			assertion: assert.Error,
+		},
+		{
+			name:      "empty",
+			tpl:       " {{if .Previous}}{{end}} ",
+			assertion: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderMessage(tt.tpl, d)
+			if !tt.assertion(t, err, "renderMessage()") || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want, got, "renderMessage()")
+		})
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	assert.NoError(t, ValidateMessage(DefaultCommitMessage))
+	assert.NoError(t, ValidateMessage(DefaultTagMessage))
+	assert.Error(t, ValidateMessage("{{.Version"))
+	assert.Error(t, ValidateMessage("{{.Unknown}}"))
+}
+
+func TestRepository_messageData(t *testing.T) {
+	viper.Set(key.DryRun, false)
+	defer viper.Reset()
+
+	_, _, r, _ := __releaseRepo(t)
+
+	date := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	d, err := r.messageData(version.V("1.2.0"), tagFormat(), date, "\n### Features\n")
+	assert.NoError(t, err)
+	assert.Equal(t, MessageData{
+		Version:  "1.2.0",
+		Previous: "1.1.0",
+		Tag:      "v1.2.0",
+		Date:     "2024-01-31",
+		Notes:    "### Features",
+	}, d)
+
+	// the version tag is not previous.
+	d, err = r.messageData(version.V("1.1.0"), tagFormat(), date, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", d.Previous)
+}
+
+func TestRepository_CommitTag_tagMessage(t *testing.T) {
+	viper.Set(key.DryRun, false)
+	defer viper.Reset()
+
+	repo, _, _, _ := __releaseRepo(t)
+
+	ref, err := repo.Tag("v1.1.0")
+	assert.NoError(t, err)
+
+	tag, err := repo.TagObject(ref.Hash())
+	if !assert.NoError(t, err, "annotated tag") {
+		return
+	}
+
+	assert.Equal(t, "chore(release): 1.1.0\n\n### Features\n\n* release notes\n", tag.Message)
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// commitTouches returns true if the commit changes files in one of the paths.
+// Changes are calculated from the first parent (all files for the root commit).
+func commitTouches(c *object.Commit, paths []string) (bool, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return false, err
+	}
+
+	var parentTree *object.Tree
+
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return false, err
+		}
+
+		if parentTree, err = parent.Tree(); err != nil {
+			return false, err
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ch := range changes {
+		if matchPaths(ch.From.Name, paths) || matchPaths(ch.To.Name, paths) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// matchPaths returns true if the file (relative to repository root) is in one of the paths.
+// Empty paths list and "." path match all files.
+func matchPaths(file string, paths []string) bool {
+	if file == "" {
+		return false
+	}
+
+	if len(paths) == 0 {
+		return true
+	}
+
+	for _, p := range paths {
+		p = path.Clean(strings.TrimPrefix(p, "./"))
+
+		if p == "." || file == p || strings.HasPrefix(file, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
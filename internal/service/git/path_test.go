@@ -0,0 +1,68 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_matchPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		paths []string
+		want  bool
+	}{
+		{
+			name: "empty paths",
+			file: "main.go",
+			want: true,
+		},
+		{
+			name:  "empty file",
+			paths: []string{"."},
+			want:  false,
+		},
+		{
+			name:  "root path",
+			file:  "main.go",
+			paths: []string{"."},
+			want:  true,
+		},
+		{
+			name:  "directory",
+			file:  "backend/cmd/main.go",
+			paths: []string{"frontend", "backend"},
+			want:  true,
+		},
+		{
+			name:  "directory with slashes",
+			file:  "backend/main.go",
+			paths: []string{"./backend/"},
+			want:  true,
+		},
+		{
+			name:  "file",
+			file:  "backend.go",
+			paths: []string{"backend.go"},
+			want:  true,
+		},
+		{
+			name:  "directory prefix",
+			file:  "backend-old/main.go",
+			paths: []string{"backend"},
+			want:  false,
+		},
+		{
+			name:  "other directory",
+			file:  "frontend/index.js",
+			paths: []string{"backend"},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchPaths(tt.file, tt.paths))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	gitcfg "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultRemote is a default remote name for push.
+	DefaultRemote = "origin"
+	// TokenEnv is an environment variable with token for push over http(s).
+	TokenEnv = "VERSION_GIT_TOKEN"
+)
+
+// ErrPush is a push error.
+var ErrPush = errors.New("push error")
+
+// credentialFill returns username and password for the endpoint from git credential helper.
+// Variable for testing.
+var credentialFill = gitCredentialFill
+
+// Push pushes the current branch and the version tag to the remote (git.pushRemote, default origin).
+func (r Repository) Push(v version.V) error {
+	if viper.GetBool(key.DryRun) {
+		return nil
+	}
+
+	name := viper.GetString(key.GitPushRemote)
+	if name == "" {
+		name = DefaultRemote
+	}
+
+	remote, err := r.repo.Remote(name)
+	if err != nil {
+		return fmt.Errorf("%w: remote %s: %w", ErrPush, name, err)
+	}
+
+	head, err := r.repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return fmt.Errorf("%w: get HEAD: %w", ErrPush, err)
+	}
+
+	if head.Type() != plumbing.SymbolicReference {
+		return fmt.Errorf("%w: HEAD is detached", ErrPush)
+	}
+
+	branch := head.Target()
+	tag := plumbing.NewTagReferenceName(TagName(v))
+
+	// go-git skips refspec with not existing source.
+	if _, err := r.repo.Reference(tag, false); err != nil {
+		return fmt.Errorf("%w: tag %s: %w", ErrPush, tag.Short(), err)
+	}
+
+	auth, err := pushAuth(remote.Config().URLs[0])
+	if err != nil {
+		return fmt.Errorf("%w: remote %s: %w", ErrPush, name, err)
+	}
+
+	err = remote.Push(&git.PushOptions{
+		RemoteName: name,
+		RefSpecs: []gitcfg.RefSpec{
+			gitcfg.RefSpec(branch + ":" + branch),
+			gitcfg.RefSpec(tag + ":" + tag),
+		},
+		Auth: auth,
+	})
+
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("%w: push %s and tag %s to %s: %w", ErrPush, branch.Short(), tag.Short(), name, err)
+	}
+
+	return nil
+}
+
+// pushAuth returns auth method for the remote url:
+//   - ssh: keys from ssh-agent;
+//   - http(s): token from VERSION_GIT_TOKEN environment variable or credentials from git credential helper;
+//   - local path (file): without auth.
+func pushAuth(u string) (transport.AuthMethod, error) {
+	ep, err := transport.NewEndpoint(u)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ep.Protocol {
+	case "ssh":
+		user := ep.User
+		if user == "" {
+			user = "git"
+		}
+
+		auth, err := ssh.NewSSHAgentAuth(user)
+		if err != nil {
+			return nil, fmt.Errorf("ssh agent: %w", err)
+		}
+
+		return auth, nil
+	case "http", "https":
+		if token := os.Getenv(TokenEnv); token != "" {
+			user := ep.User
+			if user == "" {
+				user = "git"
+			}
+
+			return &http.BasicAuth{Username: user, Password: token}, nil
+		}
+
+		if ep.User != "" && ep.Password != "" {
+			return &http.BasicAuth{Username: ep.User, Password: ep.Password}, nil
+		}
+
+		user, password, err := credentialFill(ep)
+		if err != nil || password == "" {
+			// anonymous push, server returns auth error, if it is required.
+			return nil, nil //nolint:nilnil
+		}
+
+		return &http.BasicAuth{Username: user, Password: password}, nil
+	default:
+		return nil, nil //nolint:nilnil
+	}
+}
+
+// gitCredentialFill returns username and password for the endpoint with "git credential fill".
+// Terminal prompt is disabled.
+func gitCredentialFill(ep *transport.Endpoint) (user, password string, err error) {
+	host := ep.Host
+	if ep.Port != 0 {
+		host = fmt.Sprintf("%s:%d", ep.Host, ep.Port)
+	}
+
+	var in bytes.Buffer
+
+	fmt.Fprintf(&in, "protocol=%s\nhost=%s\npath=%s\n", ep.Protocol, host, strings.TrimPrefix(ep.Path, "/"))
+
+	if ep.User != "" {
+		fmt.Fprintf(&in, "username=%s\n", ep.User)
+	}
+
+	in.WriteString("\n")
+
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = &in
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("git credential fill: %w", err)
+	}
+
+	return parseCredentials(out)
+}
+
+// parseCredentials returns username and password from "git credential fill" output.
+func parseCredentials(out []byte) (user, password string, err error) {
+	s := bufio.NewScanner(bytes.NewReader(out))
+
+	for s.Scan() {
+		k, v, ok := strings.Cut(s.Text(), "=")
+		if !ok {
+			continue
+		}
+
+		switch k {
+		case "username":
+			user = v
+		case "password":
+			password = v
+		}
+	}
+
+	return user, password, s.Err()
+}
@@ -0,0 +1,322 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+)
+
+// ErrNoRelease is returned, if HEAD is not a release commit, that can be undone.
+var ErrNoRelease = errors.New("no release to undo")
+
+// Release is the last release commit, created by CommitTag.
+type Release struct {
+	// Version is a release version.
+	Version version.V
+	// Tag is a release tag name.
+	Tag string
+	// Branch is a branch of the release commit.
+	Branch plumbing.ReferenceName
+	// Hash is a release commit hash.
+	Hash plumbing.Hash
+	// Parent is a parent commit hash (HEAD after undo).
+	Parent plumbing.Hash
+	// Files are files, changed by the release commit.
+	Files []string
+}
+
+// LastRelease returns the release commit, if it can be undone:
+// HEAD is a commit on a branch, the release tag points at it, the commit subject matches
+// the git.commitMessage template and the commit is not pushed (is not in the remote-tracking branch).
+// Tag name is checked by config tag format and by formats of projects.
+func (r Repository) LastRelease(projects ...TagFormat) (Release, error) {
+	rel := Release{}
+
+	head, err := r.repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return rel, fmt.Errorf("get HEAD error: %w", err)
+	}
+
+	if head.Type() != plumbing.SymbolicReference {
+		return rel, fmt.Errorf("%w: HEAD is detached", ErrNoRelease)
+	}
+
+	rel.Branch = head.Target()
+
+	ref, err := r.repo.Reference(rel.Branch, true)
+	if err != nil {
+		return rel, fmt.Errorf("%w: get branch %s error: %w", ErrNoRelease, rel.Branch.Short(), err)
+	}
+
+	commit, err := r.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return rel, fmt.Errorf("get commit %s error: %w", ref.Hash(), err)
+	}
+
+	rel.Hash = commit.Hash
+
+	f, err := r.releaseTag(commit.Hash, append([]TagFormat{tagFormat()}, projects...))
+	if err != nil {
+		return rel, err
+	}
+
+	rel.Tag, rel.Version = f.name, f.ver
+
+	if err := r.checkReleaseMessage(commit, rel.Version, f.format); err != nil {
+		return rel, err
+	}
+
+	if commit.NumParents() == 0 {
+		return rel, fmt.Errorf("%w: release commit %s has no parent", ErrNoRelease, commit.Hash.String())
+	}
+
+	rel.Parent = commit.ParentHashes[0]
+
+	if err := r.checkNotPushed(commit, rel.Branch); err != nil {
+		return rel, err
+	}
+
+	if rel.Files, err = commitFiles(commit); err != nil {
+		return rel, err
+	}
+
+	return rel, nil
+}
+
+// Undo deletes the release tag and resets the branch to the parent commit (soft reset: index and files are kept).
+// If restore is true, then files of the release commit are restored from the parent commit.
+func (r Repository) Undo(rel Release, restore bool) error {
+	if viper.GetBool(key.DryRun) {
+		return nil
+	}
+
+	if err := r.repo.Storer.RemoveReference(plumbing.NewTagReferenceName(rel.Tag)); err != nil {
+		return fmt.Errorf("delete tag %s error: %w", rel.Tag, err)
+	}
+
+	if err := r.repo.Storer.SetReference(plumbing.NewHashReference(rel.Branch, rel.Parent)); err != nil {
+		return fmt.Errorf("reset %s error: %w", rel.Branch.Short(), err)
+	}
+
+	if !restore {
+		return nil
+	}
+
+	return r.restoreFiles(rel)
+}
+
+// releaseTagMatch is a release tag, that points at the commit, with the matched tag format.
+type releaseTagMatch struct {
+	name   string
+	ver    version.V
+	format TagFormat
+}
+
+// releaseTag returns the release tag, that points at the commit.
+// Tag name is parsed by formats in order.
+func (r Repository) releaseTag(hash plumbing.Hash, formats []TagFormat) (releaseTagMatch, error) {
+	tagRefs, err := r.repo.Tags()
+	if err != nil {
+		return releaseTagMatch{}, fmt.Errorf("get tags error: %w", err)
+	}
+
+	defer tagRefs.Close()
+
+	found := make(map[string]version.V)
+
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		target := ref.Hash()
+
+		// annotated tag points at the tag object.
+		if t, err := r.repo.TagObject(target); err == nil {
+			target = t.Target
+		}
+
+		if target != hash {
+			return nil
+		}
+
+		for _, f := range formats {
+			if v, ok := f.Parse(ref.Name().Short()); ok {
+				found[ref.Name().Short()] = v
+
+				return nil
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return releaseTagMatch{}, fmt.Errorf("get tags error: %w", err)
+	}
+
+	for _, f := range formats {
+		for name, v := range found {
+			if f.Name(v) == name {
+				return releaseTagMatch{name: name, ver: v, format: f}, nil
+			}
+		}
+	}
+
+	return releaseTagMatch{}, fmt.Errorf("%w: release tag does not point at HEAD", ErrNoRelease)
+}
+
+// checkReleaseMessage returns error, if the commit subject does not match the release commit message template.
+// Release notes are not used, because they are not known after the release.
+// Tag and previous version are taken by the tag format of the release tag (config or project format).
+func (r Repository) checkReleaseMessage(commit *object.Commit, v version.V, f TagFormat) error {
+	d, err := r.messageData(v, f, commit.Committer.When, "")
+	if err != nil {
+		return fmt.Errorf("get release message data error: %w", err)
+	}
+
+	msg, err := renderMessage(commitMessageTpl(), d)
+	if err != nil {
+		return fmt.Errorf("commit message error: %w", err)
+	}
+
+	if subject(commit.Message) != subject(msg) {
+		return fmt.Errorf("%w: HEAD commit %q is not a release commit", ErrNoRelease, subject(commit.Message))
+	}
+
+	return nil
+}
+
+// checkNotPushed returns error, if the commit is in the remote-tracking branch of the branch.
+// If the branch has no remote-tracking branch, then the commit is not pushed.
+func (r Repository) checkNotPushed(commit *object.Commit, branch plumbing.ReferenceName) error {
+	remote, merge := "origin", branch
+
+	cfg, err := r.repo.Config()
+	if err != nil {
+		return fmt.Errorf("get config error: %w", err)
+	}
+
+	if b, ok := cfg.Branches[branch.Short()]; ok && b.Remote != "" {
+		remote = b.Remote
+
+		if b.Merge != "" {
+			merge = b.Merge
+		}
+	}
+
+	ref, err := r.repo.Reference(plumbing.NewRemoteReferenceName(remote, merge.Short()), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("get remote-tracking branch error: %w", err)
+	}
+
+	if ref.Hash() == commit.Hash {
+		return fmt.Errorf("%w: release commit is pushed to %s", ErrNoRelease, ref.Name().Short())
+	}
+
+	tracking, err := r.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return fmt.Errorf("get commit %s error: %w", ref.Hash(), err)
+	}
+
+	pushed, err := commit.IsAncestor(tracking)
+	if err != nil {
+		return fmt.Errorf("check remote-tracking branch error: %w", err)
+	}
+
+	if pushed {
+		return fmt.Errorf("%w: release commit is pushed to %s", ErrNoRelease, ref.Name().Short())
+	}
+
+	return nil
+}
+
+// restoreFiles restores files of the release commit in the worktree and index from the parent commit.
+// Files, added by the release commit, are removed.
+func (r Repository) restoreFiles(rel Release) error {
+	w, err := r.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("get worktree error: %w", err)
+	}
+
+	parent, err := r.repo.CommitObject(rel.Parent)
+	if err != nil {
+		return fmt.Errorf("get commit %s error: %w", rel.Parent, err)
+	}
+
+	for _, name := range rel.Files {
+		f, err := parent.File(name)
+		if errors.Is(err, object.ErrFileNotFound) {
+			if _, err := w.Remove(name); err != nil {
+				return fmt.Errorf("remove file %s error: %w", name, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("get file %s error: %w", name, err)
+		}
+
+		content, err := f.Contents()
+		if err != nil {
+			return fmt.Errorf("read file %s error: %w", name, err)
+		}
+
+		mode, err := f.Mode.ToOSFileMode()
+		if err != nil {
+			return fmt.Errorf("file %s mode error: %w", name, err)
+		}
+
+		if err := util.WriteFile(w.Filesystem, name, []byte(content), mode); err != nil {
+			return fmt.Errorf("write file %s error: %w", name, err)
+		}
+
+		if _, err := w.Add(name); err != nil {
+			return fmt.Errorf("add file %s error: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// commitFiles returns names of the files, changed by the commit (compared with the first parent).
+func commitFiles(c *object.Commit) ([]string, error) {
+	parent, err := c.Parent(0)
+	if err != nil {
+		return nil, fmt.Errorf("get parent commit error: %w", err)
+	}
+
+	from, err := parent.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("get tree error: %w", err)
+	}
+
+	to, err := c.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("get tree error: %w", err)
+	}
+
+	changes, err := from.Diff(to)
+	if err != nil {
+		return nil, fmt.Errorf("diff commit error: %w", err)
+	}
+
+	files := make([]string, 0, len(changes))
+
+	for _, ch := range changes {
+		name := ch.To.Name
+		if name == "" {
+			name = ch.From.Name
+		}
+
+		files = append(files, name)
+	}
+
+	return files, nil
+}
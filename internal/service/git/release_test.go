@@ -0,0 +1,280 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// __releaseRepo returns a memory repository with the first commit (tag v1.0.0) and the release commit 1.1.0.
+func __releaseRepo(t *testing.T) (*git.Repository, billy.Filesystem, *Repository, plumbing.Hash) {
+	t.Helper()
+
+	fs := memfs.New()
+
+	repo, err := git.Init(memory.NewStorage(), fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.User.Name, cfg.User.Email = "test", "test@example.com"
+
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig := &object.Signature{Name: "test", Email: "test@example.com"}
+
+	assert.NoError(t, util.WriteFile(fs, "file.txt", []byte("1.0.0"), 0o644))
+	_, err = w.Add("file.txt")
+	assert.NoError(t, err)
+	first, err := w.Commit("feat: init", &git.CommitOptions{Author: sig})
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", first, nil)
+	assert.NoError(t, err)
+
+	r, err := NewRepository(func(options *RepoOptions) {
+		options.Repo = repo
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, util.WriteFile(fs, "file.txt", []byte("1.1.0"), 0o644))
+	assert.NoError(t, util.WriteFile(fs, "CHANGELOG.md", []byte("# 1.1.0"), 0o644))
+	_, err = w.Add("file.txt")
+	assert.NoError(t, err)
+	_, err = w.Add("CHANGELOG.md")
+	assert.NoError(t, err)
+	assert.NoError(t, r.CommitTag(version.V("1.1.0"), "### Features\n\n* release notes"))
+
+	return repo, fs, r, first
+}
+
+func TestRepository_LastRelease(t *testing.T) {
+	viper.Set(key.DryRun, false)
+	defer viper.Reset()
+
+	t.Run("release", func(t *testing.T) {
+		_, _, r, first := __releaseRepo(t)
+
+		rel, err := r.LastRelease()
+		assert.NoError(t, err)
+		assert.Equal(t, version.V("1.1.0"), rel.Version)
+		assert.Equal(t, "v1.1.0", rel.Tag)
+		assert.Equal(t, plumbing.Master, rel.Branch)
+		assert.Equal(t, first, rel.Parent)
+		assert.ElementsMatch(t, []string{"file.txt", "CHANGELOG.md"}, rel.Files)
+	})
+
+	t.Run("project tag", func(t *testing.T) {
+		repo, _, r, _ := __releaseRepo(t)
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+		assert.NoError(t, repo.DeleteTag("v1.1.0"))
+		_, err = repo.CreateTag("backend/v1.1.0", head.Hash(), nil)
+		assert.NoError(t, err)
+
+		_, err = r.LastRelease()
+		assert.ErrorIs(t, err, ErrNoRelease)
+
+		f, err := NewTagFormat("backend/v", "")
+		assert.NoError(t, err)
+
+		rel, err := r.LastRelease(f)
+		assert.NoError(t, err)
+		assert.Equal(t, "backend/v1.1.0", rel.Tag)
+	})
+
+	t.Run("project tag in commit message", func(t *testing.T) {
+		// next --project: project tag format and commit message with {{.Tag}}.
+		viper.Set(key.GitTagPrefix, "backend/v")
+		viper.Set(key.GitCommitMessage, "release {{.Tag}}")
+		defer viper.Set(key.GitCommitMessage, "")
+
+		repo, _, r, _ := __releaseRepo(t)
+
+		viper.Set(key.GitTagPrefix, "v")
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+		c, err := repo.CommitObject(head.Hash())
+		assert.NoError(t, err)
+		assert.Equal(t, "release backend/v1.1.0", c.Message)
+
+		f, err := NewTagFormat("backend/v", "")
+		assert.NoError(t, err)
+
+		rel, err := r.LastRelease(f)
+		assert.NoError(t, err)
+		assert.Equal(t, "backend/v1.1.0", rel.Tag)
+		assert.Equal(t, version.V("1.1.0"), rel.Version)
+	})
+
+	t.Run("custom commit message", func(t *testing.T) {
+		viper.Set(key.GitCommitMessage, "[REL-1] release {{.Version}} after {{.Previous}}")
+		defer viper.Set(key.GitCommitMessage, "")
+
+		repo, _, r, _ := __releaseRepo(t)
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+		c, err := repo.CommitObject(head.Hash())
+		assert.NoError(t, err)
+		assert.Equal(t, "[REL-1] release 1.1.0 after 1.0.0", c.Message)
+
+		rel, err := r.LastRelease()
+		assert.NoError(t, err)
+		assert.Equal(t, version.V("1.1.0"), rel.Version)
+
+		viper.Set(key.GitCommitMessage, "release {{.Version}}")
+
+		_, err = r.LastRelease()
+		assert.ErrorIs(t, err, ErrNoRelease)
+	})
+
+	t.Run("not release commit", func(t *testing.T) {
+		repo, fs, r, _ := __releaseRepo(t)
+
+		w, err := repo.Worktree()
+		assert.NoError(t, err)
+		assert.NoError(t, util.WriteFile(fs, "file.txt", []byte("fix"), 0o644))
+		_, err = w.Add("file.txt")
+		assert.NoError(t, err)
+		_, err = w.Commit("fix: file", &git.CommitOptions{})
+		assert.NoError(t, err)
+
+		_, err = r.LastRelease()
+		assert.ErrorIs(t, err, ErrNoRelease)
+	})
+
+	t.Run("tag not found", func(t *testing.T) {
+		repo, _, r, _ := __releaseRepo(t)
+
+		assert.NoError(t, repo.DeleteTag("v1.1.0"))
+
+		_, err := r.LastRelease()
+		assert.ErrorIs(t, err, ErrNoRelease)
+	})
+
+	t.Run("tag points at other commit", func(t *testing.T) {
+		repo, _, r, first := __releaseRepo(t)
+
+		assert.NoError(t, repo.DeleteTag("v1.1.0"))
+		_, err := repo.CreateTag("v1.1.0", first, nil)
+		assert.NoError(t, err)
+
+		_, err = r.LastRelease()
+		assert.ErrorIs(t, err, ErrNoRelease)
+	})
+
+	t.Run("pushed", func(t *testing.T) {
+		repo, _, r, _ := __releaseRepo(t)
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "master"), head.Hash())))
+
+		_, err = r.LastRelease()
+		assert.ErrorIs(t, err, ErrNoRelease)
+	})
+
+	t.Run("remote behind", func(t *testing.T) {
+		_, _, r, first := __releaseRepo(t)
+
+		assert.NoError(t, r.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "master"), first)))
+
+		_, err := r.LastRelease()
+		assert.NoError(t, err)
+	})
+}
+
+func TestRepository_Undo(t *testing.T) {
+	defer viper.Reset()
+
+	t.Run("soft", func(t *testing.T) {
+		viper.Set(key.DryRun, false)
+
+		repo, fs, r, first := __releaseRepo(t)
+
+		rel, err := r.LastRelease()
+		assert.NoError(t, err)
+		assert.NoError(t, r.Undo(rel, false))
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+		assert.Equal(t, first, head.Hash(), "HEAD is reset")
+
+		_, err = repo.Reference(plumbing.NewTagReferenceName("v1.1.0"), true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound, "tag is removed")
+
+		b, err := util.ReadFile(fs, "file.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, "1.1.0", string(b), "file is kept")
+
+		w, err := repo.Worktree()
+		assert.NoError(t, err)
+		st, err := w.Status()
+		assert.NoError(t, err)
+		assert.Equal(t, git.Modified, st.File("file.txt").Staging, "changes are staged")
+		assert.Equal(t, git.Added, st.File("CHANGELOG.md").Staging)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		viper.Set(key.DryRun, false)
+
+		repo, fs, r, _ := __releaseRepo(t)
+
+		rel, err := r.LastRelease()
+		assert.NoError(t, err)
+		assert.NoError(t, r.Undo(rel, true))
+
+		b, err := util.ReadFile(fs, "file.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, "1.0.0", string(b), "file is restored")
+
+		_, err = fs.Stat("CHANGELOG.md")
+		assert.Error(t, err, "added file is removed")
+
+		w, err := repo.Worktree()
+		assert.NoError(t, err)
+		st, err := w.Status()
+		assert.NoError(t, err)
+		assert.True(t, st.IsClean(), st.String())
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		viper.Set(key.DryRun, false)
+
+		repo, _, r, _ := __releaseRepo(t)
+
+		viper.Set(key.DryRun, true)
+
+		rel, err := r.LastRelease()
+		assert.NoError(t, err)
+		assert.NoError(t, r.Undo(rel, true))
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+		assert.Equal(t, rel.Hash, head.Hash(), "HEAD is not changed")
+	})
+}
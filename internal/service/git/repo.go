@@ -8,12 +8,14 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/internal/service/console"
 	"github.com/klimby/version/internal/service/fsys"
 	"github.com/klimby/version/pkg/version"
 	"github.com/spf13/viper"
@@ -24,13 +26,47 @@ type NextType int
 
 // NextType values.
 const (
-	NextNone   NextType = iota // NextNone is a next version type none (invalid).
-	NextMajor  NextType = iota // NextMajor is a next version type major.
-	NextMinor                  // NextMinor is a next version type minor.
-	NextPatch                  // NextPatch is a next version type patch.
-	NextCustom                 // NextCustom is a next version type custom (need to set custom version).
+	NextNone    NextType = iota // NextNone is a next version type none (invalid).
+	NextMajor   NextType = iota // NextMajor is a next version type major.
+	NextMinor                   // NextMinor is a next version type minor.
+	NextPatch                   // NextPatch is a next version type patch.
+	NextCustom                  // NextCustom is a next version type custom (need to set custom version).
+	NextRelease                 // NextRelease is a release of the current pre-release version.
 )
 
+// String returns a string representation of NextType.
+func (n NextType) String() string {
+	switch n {
+	case NextMajor:
+		return "major"
+	case NextMinor:
+		return "minor"
+	case NextPatch:
+		return "patch"
+	case NextCustom:
+		return "custom"
+	case NextRelease:
+		return "release"
+	default:
+		return "none"
+	}
+}
+
+// Higher returns true if the n version level is higher than o (major > minor > patch > none).
+// NextCustom and NextRelease have no level and are never higher.
+func (n NextType) Higher(o NextType) bool {
+	if !n.isLevel() {
+		return false
+	}
+
+	return !o.isLevel() || n < o
+}
+
+// isLevel returns true if the type is major, minor or patch.
+func (n NextType) isLevel() bool {
+	return n == NextMajor || n == NextMinor || n == NextPatch
+}
+
 var (
 	errTagsNotFound = errors.New("tags not found")
 )
@@ -39,18 +75,24 @@ var (
 type Repository struct {
 	repo *git.Repository
 	path string
+	warn func(string)
+	// bareTagsWarning prints the warning about tags without prefix once per repository.
+	bareTagsWarning *sync.Once
 }
 
 // RepoOptions is a Repository options.
 type RepoOptions struct {
 	Path string
 	Repo *git.Repository
+	// Warn prints a warning. Default: console.Warn.
+	Warn func(string)
 }
 
 // NewRepository returns a new Repository.
 func NewRepository(opts ...func(options *RepoOptions)) (*Repository, error) {
 	ro := &RepoOptions{
 		Path: viper.GetString(key.WorkDir),
+		Warn: console.Warn,
 	}
 
 	for _, opt := range opts {
@@ -59,7 +101,9 @@ func NewRepository(opts ...func(options *RepoOptions)) (*Repository, error) {
 
 	if ro.Repo != nil {
 		return &Repository{
-			repo: ro.Repo,
+			repo:            ro.Repo,
+			warn:            ro.Warn,
+			bareTagsWarning: &sync.Once{},
 		}, nil
 	}
 
@@ -69,8 +113,10 @@ func NewRepository(opts ...func(options *RepoOptions)) (*Repository, error) {
 	}
 
 	return &Repository{
-		repo: r,
-		path: ro.Path,
+		repo:            r,
+		path:            ro.Path,
+		warn:            ro.Warn,
+		bareTagsWarning: &sync.Once{},
 	}, nil
 }
 
@@ -159,11 +205,26 @@ func (r Repository) RemoteURL() (string, error) {
 	return "", nil
 }
 
+// HeadHash returns a hash of the HEAD commit.
+func (r Repository) HeadHash() (string, error) {
+	ref, err := r.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("get head error: %w", err)
+	}
+
+	return ref.Hash().String(), nil
+}
+
 // Current returns a current version.
 func (r Repository) Current() (version.V, error) {
+	return r.CurrentFor(tagFormat())
+}
+
+// CurrentFor returns a current version for tag format (for example, sub-project version).
+func (r Repository) CurrentFor(f TagFormat) (version.V, error) {
 	var lastV version.V
 
-	lastTag, err := r.lastTag()
+	lastTag, err := r.lastTag(f)
 	if err != nil {
 		if !errors.Is(err, errTagsNotFound) {
 			return "", err
@@ -182,7 +243,9 @@ func (r Repository) Current() (version.V, error) {
 }
 
 // NextVersion returns a next version.
-func (r Repository) NextVersion(nt NextType, custom version.V) (_ version.V, exists bool, _ error) {
+// If pre is not empty, then returns the next pre-release version with pre identifier:
+// 1.2.3 -> 1.3.0-rc.1 for minor, 1.3.0-rc.1 -> 1.3.0-rc.2 for minor or patch.
+func (r Repository) NextVersion(nt NextType, custom version.V, pre string) (_ version.V, exists bool, _ error) {
 	if nt == NextNone {
 		return "", false, nil
 	}
@@ -203,10 +266,24 @@ func (r Repository) NextVersion(nt NextType, custom version.V) (_ version.V, exi
 		next = lastV.NextPatch()
 	case NextCustom:
 		next = custom
+	case NextRelease:
+		if !lastV.IsPrerelease() {
+			return "", false, fmt.Errorf("current version %s is not a pre-release", lastV.FormatString())
+		}
+
+		next = lastV.Release()
 	case NextNone:
 		return "", false, fmt.Errorf("unknown next type")
 	}
 
+	if pre != "" && nt != NextCustom && nt != NextRelease {
+		if lastV.IsPrerelease() && lastV.Release().Equal(next) {
+			next = lastV.NextPrerelease(pre)
+		} else {
+			next = next.NextPrerelease(pre)
+		}
+	}
+
 	for {
 		exists, err := r.versionExists(next)
 		if err != nil {
@@ -217,7 +294,11 @@ func (r Repository) NextVersion(nt NextType, custom version.V) (_ version.V, exi
 			break
 		}
 
-		next = next.NextPatch()
+		if pre != "" && next.IsPrerelease() {
+			next = next.NextPrerelease(pre)
+		} else {
+			next = next.NextPatch()
+		}
 	}
 
 	return next, exists, nil
@@ -225,7 +306,7 @@ func (r Repository) NextVersion(nt NextType, custom version.V) (_ version.V, exi
 
 // CheckDowngrade checks if the version is not downgraded.
 func (r Repository) CheckDowngrade(v version.V) error {
-	lastTag, err := r.lastTag()
+	lastTag, err := r.lastTag(tagFormat())
 	if err != nil {
 		if errors.Is(err, errTagsNotFound) {
 			return nil
@@ -271,24 +352,45 @@ func (r Repository) Add(files ...fsys.File) error {
 }
 
 // CommitTag stores a tag and commit changes.
-func (r Repository) CommitTag(v version.V) error {
+// Commit and tag messages are created by git.commitMessage and git.tagMessage templates,
+// notes are the release notes section of the version.
+// If git.sign is set, then the commit and the tag are signed.
+func (r Repository) CommitTag(v version.V, notes string) error {
 	if viper.GetBool(key.DryRun) {
 		return nil
 	}
 
+	d, err := r.messageData(v, tagFormat(), time.Now(), notes)
+	if err != nil {
+		return fmt.Errorf("get release message data error: %w", err)
+	}
+
+	commitMsg, err := renderMessage(commitMessageTpl(), d)
+	if err != nil {
+		return fmt.Errorf("commit message error: %w", err)
+	}
+
+	tagMsg, err := renderMessage(tagMessageTpl(), d)
+	if err != nil {
+		return fmt.Errorf("tag message error: %w", err)
+	}
+
+	signer, err := r.signer()
+	if err != nil {
+		return fmt.Errorf("load sign key error: %w", err)
+	}
+
 	w, err := r.repo.Worktree()
 	if err != nil {
 		return fmt.Errorf("get worktree error: %w", err)
 	}
 
-	commit, err := w.Commit(fmt.Sprintf("chore(release): %s", v.FormatString()), &git.CommitOptions{})
+	commit, err := w.Commit(commitMsg, &git.CommitOptions{Signer: signer})
 	if err != nil {
 		return fmt.Errorf("commit error: %w", err)
 	}
 
-	if _, err = r.repo.CreateTag(v.GitVersion(), commit, &git.CreateTagOptions{
-		Message: fmt.Sprintf("chore(release): %s", v.FormatString()),
-	}); err != nil {
+	if err := r.createTag(d.Tag, commit, tagMsg, signer); err != nil {
 		return fmt.Errorf("create tag error: %w", err)
 	}
 
@@ -300,6 +402,10 @@ type CommitsArgs struct {
 	NextV         version.V
 	LastOnly      bool
 	IncludeMerges bool
+	// Paths is a list of paths for filter commits (project paths).
+	// Only commits, that change files in these paths, and tagged commits are returned.
+	// Default: project paths from config, empty - all commits.
+	Paths []string
 }
 
 // Commits returns commits.
@@ -311,13 +417,14 @@ func (r Repository) Commits(opt ...func(options *CommitsArgs)) ([]Commit, error)
 		NextV:         version.V(""),
 		LastOnly:      false,
 		IncludeMerges: false,
+		Paths:         viper.GetStringSlice(key.ProjectPaths),
 	}
 
 	for _, o := range opt {
 		o(a)
 	}
 
-	tags, err := r.tags()
+	tags, err := r.tags(tagFormat())
 	if err != nil {
 		return nil, err
 	}
@@ -336,7 +443,7 @@ func (r Repository) Commits(opt ...func(options *CommitsArgs)) ([]Commit, error)
 	if !a.NextV.Empty() {
 		lastCommit := Commit{
 			Hash:    plumbing.ZeroHash.String(),
-			Message: fmt.Sprintf("chore(release): %s", a.NextV.GitVersion()),
+			Message: r.nextCommitMessage(a.NextV, tags),
 			Version: a.NextV,
 			Date:    time.Now(),
 		}
@@ -367,12 +474,44 @@ func (r Repository) Commits(opt ...func(options *CommitsArgs)) ([]Commit, error)
 			continue
 		}
 
+		if len(a.Paths) > 0 && !cmt.IsTag() {
+			touches, err := commitTouches(c, a.Paths)
+			if err != nil {
+				return nil, fmt.Errorf("get commit %s changes error: %w", cmt.Hash, err)
+			}
+
+			if !touches {
+				continue
+			}
+		}
+
 		cs = append(cs, cmt)
 	}
 
 	return cs, nil
 }
 
+// nextCommitMessage returns the release commit message of the not created version (without notes).
+// If the template is invalid, then returns the default message.
+func (r Repository) nextCommitMessage(v version.V, tags []tagCommit) string {
+	d := MessageData{
+		Version: v.FormatString(),
+		Tag:     TagName(v),
+		Date:    time.Now().Format(_messageDateFormat),
+	}
+
+	if len(tags) > 0 {
+		d.Previous = tags[len(tags)-1].ver.FormatString()
+	}
+
+	msg, err := renderMessage(commitMessageTpl(), d)
+	if err != nil {
+		msg, _ = renderMessage(DefaultCommitMessage, d)
+	}
+
+	return msg
+}
+
 // setTagToCommit sets a tag to commit.
 func setTagToCommit(c *Commit, tags []tagCommit) {
 	for i := range tags {
@@ -386,7 +525,7 @@ func setTagToCommit(c *Commit, tags []tagCommit) {
 
 // versionExists returns true if the tag exists.
 func (r Repository) versionExists(v version.V) (bool, error) {
-	tags, err := r.tags()
+	tags, err := r.tags(tagFormat())
 	if err != nil {
 		return false, err
 	}
@@ -400,9 +539,9 @@ func (r Repository) versionExists(v version.V) (bool, error) {
 	return false, nil
 }
 
-// lastTag returns a last tag.
-func (r Repository) lastTag() (*tagCommit, error) {
-	tags, err := r.tags()
+// lastTag returns a last tag for tag format.
+func (r Repository) lastTag(f TagFormat) (*tagCommit, error) {
+	tags, err := r.tags(f)
 	if err != nil {
 		return nil, err
 	}
@@ -414,8 +553,8 @@ func (r Repository) lastTag() (*tagCommit, error) {
 	return &tags[len(tags)-1], nil
 }
 
-// tags returns a list of tags.
-func (r Repository) tags() ([]tagCommit, error) {
+// tags returns a list of tags, that match the tag format.
+func (r Repository) tags(f TagFormat) ([]tagCommit, error) {
 	tagRefs, err := r.repo.Tags()
 	if err != nil {
 		return nil, err
@@ -423,7 +562,11 @@ func (r Repository) tags() ([]tagCommit, error) {
 
 	defer tagRefs.Close()
 
-	var tags []tagCommit
+	var (
+		tags []tagCommit
+		// bare is true, if there are tags without prefix (1.2.3), that do not match the format.
+		bare bool
+	)
 
 	for {
 		tagRef, err := tagRefs.Next()
@@ -435,27 +578,37 @@ func (r Repository) tags() ([]tagCommit, error) {
 			return nil, err
 		}
 
-		tC := r.tagCommitFromRef(tagRef)
+		tC := r.tagCommitFromRef(tagRef, f)
 
 		if tC != nil {
 			tags = append(tags, *tC)
+		} else if _, ok := (TagFormat{}).Parse(tagRef.Name().Short()); ok {
+			bare = true
 		}
 	}
 
+	if len(tags) == 0 && bare && f == tagFormat() {
+		r.bareTagsWarning.Do(func() {
+			r.warn(fmt.Sprintf("No tags match the tag format %s, but tags without prefix (1.2.3) exist: "+
+				`set git.tagPrefix: "" in config to use them`, f.Name("X.Y.Z")))
+		})
+	}
+
 	slices.SortFunc(tags, version.CompareASC[tagCommit])
 
 	return tags, nil
 }
 
 // tagCommitFromRef returns a tagCommit from plumbing.Reference.
-func (r Repository) tagCommitFromRef(ref *plumbing.Reference) *tagCommit {
+// Returns nil, if the tag name does not match the tag format.
+func (r Repository) tagCommitFromRef(ref *plumbing.Reference, f TagFormat) *tagCommit {
 	if ref == nil || !ref.Name().IsTag() {
 		return nil
 	}
 
 	// Short name for tag ref - tag name (version in our case).
-	v := version.V(ref.Name().Short())
-	if v.Invalid() {
+	v, ok := f.Parse(ref.Name().Short())
+	if !ok {
 		return nil
 	}
 
@@ -533,7 +686,7 @@ func (c Commit) String() string {
 	b.WriteString(c.Hash[:7] + " | " + strings.Split(c.Message, "\n")[0])
 
 	if c.IsTag() {
-		b.WriteString(" | " + c.Version.GitVersion())
+		b.WriteString(" | " + TagName(c.Version))
 	}
 
 	return b.String()
@@ -571,7 +724,7 @@ type tagCommit struct {
 
 // String returns a tag string.
 func (t tagCommit) String() string {
-	return t.commitHash[:7] + " | " + t.ver.GitVersion()
+	return t.commitHash[:7] + " | " + TagName(t.ver)
 }
 
 // Version returns a tag version.
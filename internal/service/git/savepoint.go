@@ -0,0 +1,130 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/spf13/viper"
+)
+
+// Savepoint is a repository state for rollback: HEAD reference, index and tags.
+type Savepoint struct {
+	// head is a reference name, that HEAD points to (branch or HEAD for detached HEAD).
+	head plumbing.ReferenceName
+	// hash is a commit hash of the head. Zero hash, if the branch has no commits.
+	hash plumbing.Hash
+	// index is an encoded staging area.
+	index []byte
+	// tags are names of existing tags.
+	tags map[plumbing.ReferenceName]bool
+}
+
+// Savepoint returns the current repository state for rollback.
+func (r Repository) Savepoint() (Savepoint, error) {
+	sp := Savepoint{
+		head: plumbing.HEAD,
+		tags: make(map[plumbing.ReferenceName]bool),
+	}
+
+	head, err := r.repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return sp, fmt.Errorf("get HEAD error: %w", err)
+	}
+
+	if head.Type() == plumbing.SymbolicReference {
+		sp.head = head.Target()
+	}
+
+	ref, err := r.repo.Reference(sp.head, true)
+
+	switch {
+	case err == nil:
+		sp.hash = ref.Hash()
+	case !errors.Is(err, plumbing.ErrReferenceNotFound):
+		return sp, fmt.Errorf("get reference %s error: %w", sp.head, err)
+	}
+
+	idx, err := r.repo.Storer.Index()
+	if err != nil {
+		return sp, fmt.Errorf("get index error: %w", err)
+	}
+
+	// index is encoded, because storage may return the same object, that is changed on add.
+	var b bytes.Buffer
+
+	if err := index.NewEncoder(&b).Encode(idx); err != nil {
+		return sp, fmt.Errorf("encode index error: %w", err)
+	}
+
+	sp.index = b.Bytes()
+
+	tags, err := r.repo.Tags()
+	if err != nil {
+		return sp, fmt.Errorf("get tags error: %w", err)
+	}
+
+	if err := tags.ForEach(func(t *plumbing.Reference) error {
+		sp.tags[t.Name()] = true
+
+		return nil
+	}); err != nil {
+		return sp, fmt.Errorf("get tags error: %w", err)
+	}
+
+	return sp, nil
+}
+
+// Rollback restores the repository state: removes new tags, resets HEAD to the saved commit and restores index.
+// Working tree files are not changed.
+func (r Repository) Rollback(sp Savepoint) error {
+	if viper.GetBool(key.DryRun) {
+		return nil
+	}
+
+	var errs []error
+
+	tags, err := r.repo.Tags()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("get tags error: %w", err))
+	} else {
+		var created []plumbing.ReferenceName
+
+		_ = tags.ForEach(func(t *plumbing.Reference) error {
+			if !sp.tags[t.Name()] {
+				created = append(created, t.Name())
+			}
+
+			return nil
+		})
+
+		for _, name := range created {
+			if err := r.repo.Storer.RemoveReference(name); err != nil {
+				errs = append(errs, fmt.Errorf("remove tag %s error: %w", name.Short(), err))
+			}
+		}
+	}
+
+	if sp.hash.IsZero() {
+		err = r.repo.Storer.RemoveReference(sp.head)
+	} else {
+		err = r.repo.Storer.SetReference(plumbing.NewHashReference(sp.head, sp.hash))
+	}
+
+	if err != nil {
+		errs = append(errs, fmt.Errorf("reset %s error: %w", sp.head.Short(), err))
+	}
+
+	idx := &index.Index{}
+
+	if err := index.NewDecoder(bytes.NewReader(sp.index)).Decode(idx); err != nil {
+		errs = append(errs, fmt.Errorf("decode index error: %w", err))
+	} else if err := r.repo.Storer.SetIndex(idx); err != nil {
+		errs = append(errs, fmt.Errorf("restore index error: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
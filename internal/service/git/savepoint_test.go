@@ -0,0 +1,86 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_Rollback(t *testing.T) {
+	viper.Set(key.DryRun, false)
+	defer viper.Reset()
+
+	fs := memfs.New()
+
+	repo, err := git.Init(memory.NewStorage(), fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.User.Name, cfg.User.Email = "test", "test@example.com"
+
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig := &object.Signature{Name: "test", Email: "test@example.com"}
+
+	assert.NoError(t, util.WriteFile(fs, "file.txt", []byte("1.0.0"), 0o644))
+	_, err = w.Add("file.txt")
+	assert.NoError(t, err)
+	first, err := w.Commit("feat: init", &git.CommitOptions{Author: sig})
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", first, nil)
+	assert.NoError(t, err)
+
+	r, err := NewRepository(func(options *RepoOptions) {
+		options.Repo = repo
+	})
+	assert.NoError(t, err)
+
+	sp, err := r.Savepoint()
+	assert.NoError(t, err)
+
+	// release commit and tag.
+	assert.NoError(t, util.WriteFile(fs, "file.txt", []byte("1.1.0"), 0o644))
+	_, err = w.Add("file.txt")
+	assert.NoError(t, err)
+	assert.NoError(t, r.CommitTag(version.V("1.1.0"), "### Features\n\n* release notes"))
+
+	assert.NoError(t, r.Rollback(sp))
+
+	head, err := repo.Head()
+	assert.NoError(t, err)
+	assert.Equal(t, first, head.Hash(), "HEAD is reset")
+
+	_, err = repo.Reference(plumbing.NewTagReferenceName("v1.1.0"), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound, "new tag is removed")
+
+	_, err = repo.Reference(plumbing.NewTagReferenceName("v1.0.0"), true)
+	assert.NoError(t, err, "old tag is kept")
+
+	// worktree file is not restored by repository rollback, so the file is modified and not staged.
+	st, err := w.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, git.Unmodified, st.File("file.txt").Staging, "index is restored")
+	assert.Equal(t, git.Modified, st.File("file.txt").Worktree)
+}
@@ -0,0 +1,455 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	gitcfg "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// SignFormat is a release commit and tag signature format.
+type SignFormat string
+
+// SignFormat values.
+const (
+	SignNone SignFormat = ""    // SignNone - commit and tag are not signed.
+	SignGPG  SignFormat = "gpg" // SignGPG - OpenPGP signature.
+	SignSSH  SignFormat = "ssh" // SignSSH - SSH signature.
+)
+
+// SignPassphraseEnv is an environment variable with passphrase for encrypted sign key.
+const SignPassphraseEnv = "VERSION_SIGN_PASSPHRASE"
+
+var (
+	// ErrSignature is a tag signature error (not signed or invalid).
+	ErrSignature = errors.New("tag signature error")
+	// ErrGPGKeyRequired is returned, if the gpg keyring path (git.signKey) is not set.
+	ErrGPGKeyRequired = errors.New("git.signKey is required for gpg sign: " +
+		"set the path of exported armored secret key (gpg --armor --export-secret-keys <key id> > key.asc)")
+)
+
+// String returns string representation of SignFormat.
+func (f SignFormat) String() string {
+	return string(f)
+}
+
+// Valid returns true if the sign format is valid.
+func (f SignFormat) Valid() bool {
+	return f == SignNone || f == SignGPG || f == SignSSH
+}
+
+// TagVerification is a result of the tag signature verification.
+type TagVerification struct {
+	// Tag is a tag name.
+	Tag string
+	// Format is a signature format.
+	Format SignFormat
+	// Key is a signing key id (gpg) or fingerprint (ssh).
+	Key string
+}
+
+// VerifyTag verifies signature of the last version tag with the key from config (git.signKey or user.signingkey).
+// Returns ErrSignature, if the tag is not signed or the signature is invalid.
+func (r Repository) VerifyTag() (TagVerification, error) {
+	res := TagVerification{}
+
+	tc, err := r.lastTag(tagFormat())
+	if err != nil {
+		return res, err
+	}
+
+	res.Tag = TagName(tc.ver)
+
+	ref, err := r.repo.Tag(res.Tag)
+	if err != nil {
+		return res, fmt.Errorf("get tag %s error: %w", res.Tag, err)
+	}
+
+	tag, err := r.repo.TagObject(ref.Hash())
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return res, fmt.Errorf("%w: tag %s is not annotated and not signed", ErrSignature, res.Tag)
+		}
+
+		return res, fmt.Errorf("get tag %s error: %w", res.Tag, err)
+	}
+
+	if tag.PGPSignature == "" {
+		return res, fmt.Errorf("%w: tag %s is not signed", ErrSignature, res.Tag)
+	}
+
+	encoded := &plumbing.MemoryObject{}
+
+	if err := tag.EncodeWithoutSignature(encoded); err != nil {
+		return res, fmt.Errorf("encode tag %s error: %w", res.Tag, err)
+	}
+
+	rd, err := encoded.Reader()
+	if err != nil {
+		return res, fmt.Errorf("encode tag %s error: %w", res.Tag, err)
+	}
+
+	message, err := io.ReadAll(rd)
+	if err != nil {
+		return res, fmt.Errorf("encode tag %s error: %w", res.Tag, err)
+	}
+
+	if strings.HasPrefix(tag.PGPSignature, _sshSigBegin) {
+		res.Format = SignSSH
+		res.Key, err = r.verifySSH(message, []byte(tag.PGPSignature))
+	} else {
+		res.Format = SignGPG
+		res.Key, err = r.verifyGPG(message, tag.PGPSignature)
+	}
+
+	if err != nil {
+		return res, fmt.Errorf("%w: tag %s: %w", ErrSignature, res.Tag, err)
+	}
+
+	return res, nil
+}
+
+// signer returns a signer by config (git.sign) or nil, if signing is disabled.
+func (r Repository) signer() (git.Signer, error) {
+	switch f := SignFormat(viper.GetString(key.GitSign)); f {
+	case SignNone:
+		return nil, nil //nolint:nilnil
+	case SignGPG:
+		entity, err := r.gpgEntity()
+		if err != nil {
+			return nil, err
+		}
+
+		return gpgSigner{entity: entity}, nil
+	case SignSSH:
+		s, err := r.sshSigner()
+		if err != nil {
+			return nil, err
+		}
+
+		return sshSigner{signer: s}, nil
+	default:
+		return nil, fmt.Errorf("unknown sign format %s", f)
+	}
+}
+
+// createTag creates an annotated tag. If the signer is not nil, then the tag is signed.
+func (r Repository) createTag(name string, hash plumbing.Hash, message string, signer git.Signer) error {
+	opts := &git.CreateTagOptions{Message: message}
+
+	if signer == nil {
+		_, err := r.repo.CreateTag(name, hash, opts)
+
+		return err
+	}
+
+	if _, err := r.repo.Tag(name); !errors.Is(err, git.ErrTagNotFound) {
+		if err == nil {
+			return git.ErrTagExists
+		}
+
+		return err
+	}
+
+	// fill tagger from git config and normalize message.
+	if err := opts.Validate(r.repo, hash); err != nil {
+		return err
+	}
+
+	tag := &object.Tag{
+		Name:       name,
+		Tagger:     *opts.Tagger,
+		Message:    opts.Message,
+		TargetType: plumbing.CommitObject,
+		Target:     hash,
+	}
+
+	encoded := &plumbing.MemoryObject{}
+
+	if err := tag.EncodeWithoutSignature(encoded); err != nil {
+		return err
+	}
+
+	rd, err := encoded.Reader()
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.Sign(rd)
+	if err != nil {
+		return fmt.Errorf("sign tag error: %w", err)
+	}
+
+	tag.PGPSignature = string(sig)
+
+	obj := r.repo.Storer.NewEncodedObject()
+
+	if err := tag.Encode(obj); err != nil {
+		return err
+	}
+
+	h, err := r.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return err
+	}
+
+	return r.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewTagReferenceName(name), h))
+}
+
+// signingKey returns git.signKey from config or user.signingkey from git config.
+func (r Repository) signingKey() (string, error) {
+	if k := viper.GetString(key.GitSignKey); k != "" {
+		return k, nil
+	}
+
+	cfg, err := r.repo.ConfigScoped(gitcfg.SystemScope)
+	if err != nil {
+		return "", fmt.Errorf("get git config error: %w", err)
+	}
+
+	return cfg.Raw.Section("user").Option("signingkey"), nil
+}
+
+// gpgKeyring returns GPG keyring and key id from config.
+// git.signKey is a path of the exported secret key (keyring), user.signingkey is a key id.
+// GnuPG 2.1+ home directory has no keyring file, so there is no default path.
+func (r Repository) gpgKeyring() (openpgp.EntityList, string, error) {
+	cfg, err := r.repo.ConfigScoped(gitcfg.SystemScope)
+	if err != nil {
+		return nil, "", fmt.Errorf("get git config error: %w", err)
+	}
+
+	id := cfg.Raw.Section("user").Option("signingkey")
+
+	path := viper.GetString(key.GitSignKey)
+	if path == "" {
+		return nil, "", ErrGPGKeyRequired
+	}
+
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, "", fmt.Errorf("read gpg keyring error: %w", err)
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		keyring, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("read gpg keyring %s error: %w", path, err)
+	}
+
+	return keyring, id, nil
+}
+
+// gpgEntity returns GPG entity with private key for signing.
+// Encrypted key is decrypted with passphrase from VERSION_SIGN_PASSPHRASE environment variable.
+func (r Repository) gpgEntity() (*openpgp.Entity, error) {
+	keyring, id, err := r.gpgKeyring()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range keyring {
+		if e.PrivateKey == nil || !gpgEntityMatch(e, id) {
+			continue
+		}
+
+		if e.PrivateKey.Encrypted {
+			if err := e.DecryptPrivateKeys([]byte(os.Getenv(SignPassphraseEnv))); err != nil {
+				return nil, fmt.Errorf("decrypt gpg key %s error (set %s): %w", e.PrimaryKey.KeyIdString(), SignPassphraseEnv, err)
+			}
+		}
+
+		return e, nil
+	}
+
+	if id != "" {
+		return nil, fmt.Errorf("gpg private key %s not found", id)
+	}
+
+	return nil, errors.New("gpg private key not found")
+}
+
+// verifyGPG verifies OpenPGP signature and returns key id.
+func (r Repository) verifyGPG(message []byte, signature string) (string, error) {
+	keyring, _, err := r.gpgKeyring()
+	if err != nil {
+		return "", err
+	}
+
+	e, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(message), strings.NewReader(signature), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return e.PrimaryKey.KeyIdString(), nil
+}
+
+// gpgEntityMatch returns true, if the entity primary key or subkey matches id (key id or fingerprint suffix).
+// Empty id matches any entity.
+func gpgEntityMatch(e *openpgp.Entity, id string) bool {
+	id = strings.ToUpper(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(id), "0x"), "!"))
+	if id == "" {
+		return true
+	}
+
+	match := func(fingerprint []byte) bool {
+		return strings.HasSuffix(fmt.Sprintf("%X", fingerprint), id)
+	}
+
+	if match(e.PrimaryKey.Fingerprint) {
+		return true
+	}
+
+	for _, s := range e.Subkeys {
+		if match(s.PublicKey.Fingerprint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// gpgSigner signs git objects with GPG key.
+type gpgSigner struct {
+	entity *openpgp.Entity
+}
+
+// Sign returns armored detached OpenPGP signature of the message.
+func (s gpgSigner) Sign(message io.Reader) ([]byte, error) {
+	var b bytes.Buffer
+
+	if err := openpgp.ArmoredDetachSign(&b, s.entity, message, nil); err != nil {
+		return nil, fmt.Errorf("gpg sign error: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// sshSigner returns SSH signer from key (git.signKey or user.signingkey):
+//   - private key file - the key is used (encrypted key is decrypted with VERSION_SIGN_PASSPHRASE);
+//   - public key file or "key::<public key>" - the key is searched in ssh-agent.
+func (r Repository) sshSigner() (ssh.Signer, error) {
+	pub, signer, err := r.sshKey(true)
+	if err != nil {
+		return nil, err
+	}
+
+	if signer != nil {
+		return signer, nil
+	}
+
+	conn, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK"))
+	if err != nil {
+		return nil, fmt.Errorf("ssh agent error: %w", err)
+	}
+
+	signers, err := agent.NewClient(conn).Signers()
+	if err != nil {
+		return nil, fmt.Errorf("ssh agent error: %w", err)
+	}
+
+	for _, s := range signers {
+		if bytes.Equal(s.PublicKey().Marshal(), pub.Marshal()) {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("ssh key %s not found in ssh agent", ssh.FingerprintSHA256(pub))
+}
+
+// verifySSH verifies SSH signature with the key from config and returns key fingerprint.
+func (r Repository) verifySSH(message, signature []byte) (string, error) {
+	want, _, err := r.sshKey(false)
+	if err != nil {
+		return "", err
+	}
+
+	pub, err := verifySSHSig(message, signature)
+	if err != nil {
+		return "", err
+	}
+
+	if !bytes.Equal(pub.Marshal(), want.Marshal()) {
+		return "", fmt.Errorf("signed with unknown key %s", ssh.FingerprintSHA256(pub))
+	}
+
+	return ssh.FingerprintSHA256(pub), nil
+}
+
+// sshKey returns SSH public key from config and private key signer, if the key is a private key file.
+// If private is false, then encrypted private key is not decrypted.
+func (r Repository) sshKey(private bool) (ssh.PublicKey, ssh.Signer, error) {
+	k, err := r.signingKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if k == "" {
+		return nil, nil, errors.New("ssh signing key is not set (git.signKey or user.signingkey)")
+	}
+
+	data := []byte(strings.TrimPrefix(k, "key::"))
+
+	if !strings.HasPrefix(k, "key::") {
+		if data, err = os.ReadFile(expandHome(k)); err != nil {
+			return nil, nil, fmt.Errorf("read ssh key error: %w", err)
+		}
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+
+	var missing *ssh.PassphraseMissingError
+
+	switch {
+	case err == nil:
+		return signer.PublicKey(), signer, nil
+	case errors.As(err, &missing) && !private && missing.PublicKey != nil:
+		return missing.PublicKey, nil, nil
+	case errors.As(err, &missing):
+		signer, err := ssh.ParsePrivateKeyWithPassphrase(data, []byte(os.Getenv(SignPassphraseEnv)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("decrypt ssh key error (set %s): %w", SignPassphraseEnv, err)
+		}
+
+		return signer.PublicKey(), signer, nil
+	}
+
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse ssh key %s error: %w", k, err)
+	}
+
+	return pub, nil, nil
+}
+
+// expandHome replaces leading ~ with the user home directory.
+func expandHome(p string) string {
+	rest, ok := strings.CutPrefix(p, "~")
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return p
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+
+	return filepath.Join(home, rest)
+}
@@ -0,0 +1,238 @@
+package git
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// __sshKeyFile writes a new ed25519 private key to the temp dir and returns the file path.
+func __sshKeyFile(t *testing.T) string {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := filepath.Join(t.TempDir(), "id_ed25519")
+
+	if err := os.WriteFile(p, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+// __gpgKeyFile writes a new armored GPG secret key to the temp dir and returns the file path and the key id.
+func __gpgKeyFile(t *testing.T) (string, string) {
+	t.Helper()
+
+	e, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+
+	w, err := armor.Encode(&b, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.SerializePrivate(w, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p := filepath.Join(t.TempDir(), "secret.asc")
+
+	if err := os.WriteFile(p, b.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return p, e.PrimaryKey.KeyIdString()
+}
+
+func TestRepository_CommitTag_sign(t *testing.T) {
+	defer viper.Reset()
+
+	t.Run("ssh", func(t *testing.T) {
+		viper.Set(key.GitSign, SignSSH.String())
+		viper.Set(key.GitSignKey, __sshKeyFile(t))
+
+		repo, _, r, _ := __releaseRepo(t)
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+
+		commit, err := repo.CommitObject(head.Hash())
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(commit.PGPSignature, _sshSigBegin), "commit is signed")
+
+		res, err := r.VerifyTag()
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.1.0", res.Tag)
+		assert.Equal(t, SignSSH, res.Format)
+		assert.True(t, strings.HasPrefix(res.Key, "SHA256:"))
+
+		// other key.
+		viper.Set(key.GitSignKey, __sshKeyFile(t))
+
+		_, err = r.VerifyTag()
+		assert.ErrorIs(t, err, ErrSignature)
+	})
+
+	t.Run("gpg", func(t *testing.T) {
+		p, id := __gpgKeyFile(t)
+
+		viper.Set(key.GitSign, SignGPG.String())
+		viper.Set(key.GitSignKey, p)
+
+		repo, _, r, _ := __releaseRepo(t)
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+
+		commit, err := repo.CommitObject(head.Hash())
+		assert.NoError(t, err)
+
+		data, err := os.ReadFile(p)
+		assert.NoError(t, err)
+
+		_, err = commit.Verify(string(data))
+		assert.NoError(t, err, "commit is signed")
+
+		res, err := r.VerifyTag()
+		assert.NoError(t, err)
+		assert.Equal(t, SignGPG, res.Format)
+		assert.Equal(t, id, res.Key)
+
+		// other key.
+		other, _ := __gpgKeyFile(t)
+		viper.Set(key.GitSignKey, other)
+
+		_, err = r.VerifyTag()
+		assert.ErrorIs(t, err, ErrSignature)
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		viper.Set(key.GitSign, SignNone.String())
+
+		repo, _, r, _ := __releaseRepo(t)
+
+		head, err := repo.Head()
+		assert.NoError(t, err)
+
+		viper.Set(key.GitSign, SignSSH.String())
+		viper.Set(key.GitSignKey, filepath.Join(t.TempDir(), "not-exists"))
+
+		assert.Error(t, r.CommitTag(version.V("1.2.0"), ""))
+
+		after, err := repo.Head()
+		assert.NoError(t, err)
+		assert.Equal(t, head.Hash(), after.Hash(), "commit is not created")
+	})
+
+	t.Run("gpg key is not set", func(t *testing.T) {
+		viper.Set(key.GitSign, SignNone.String())
+
+		_, _, r, _ := __releaseRepo(t)
+
+		viper.Set(key.GitSign, SignGPG.String())
+		viper.Set(key.GitSignKey, "")
+
+		assert.ErrorIs(t, r.CommitTag(version.V("1.2.0"), ""), ErrGPGKeyRequired)
+	})
+
+	t.Run("not signed", func(t *testing.T) {
+		viper.Set(key.GitSign, SignNone.String())
+		viper.Set(key.GitSignKey, "")
+
+		_, _, r, _ := __releaseRepo(t)
+
+		_, err := r.VerifyTag()
+		assert.ErrorIs(t, err, ErrSignature)
+	})
+}
+
+func Test_sshSig(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, k := range map[string]any{"ed25519": edKey, "rsa": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := ssh.NewSignerFromKey(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			message := []byte("object 1234\ntype commit\n\nchore(release): 1.2.3\n")
+
+			sig, err := sshSigner{signer: signer}.Sign(bytes.NewReader(message))
+			assert.NoError(t, err)
+
+			for _, line := range strings.Split(strings.TrimSpace(string(sig)), "\n") {
+				assert.LessOrEqual(t, len(line), _sshSigLineLen)
+			}
+
+			pub, err := verifySSHSig(message, sig)
+			assert.NoError(t, err)
+			assert.Equal(t, signer.PublicKey().Marshal(), pub.Marshal())
+
+			_, err = verifySSHSig([]byte("other"), sig)
+			assert.Error(t, err, "other message")
+		})
+	}
+}
+
+func Test_gpgEntityMatch(t *testing.T) {
+	e, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := e.PrimaryKey.KeyIdString()
+
+	assert.True(t, gpgEntityMatch(e, ""))
+	assert.True(t, gpgEntityMatch(e, id))
+	assert.True(t, gpgEntityMatch(e, "0x"+strings.ToLower(id)+"!"))
+	assert.True(t, gpgEntityMatch(e, e.PrimaryKey.KeyIdShortString()))
+	assert.False(t, gpgEntityMatch(e, "0000000000000000"))
+}
+
+func TestSignFormat_Valid(t *testing.T) {
+	assert.True(t, SignNone.Valid())
+	assert.True(t, SignGPG.Valid())
+	assert.True(t, SignSSH.Valid())
+	assert.False(t, SignFormat("x509").Valid())
+}
@@ -0,0 +1,191 @@
+package git
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSH signature format (git gpg.format=ssh), see PROTOCOL.sshsig in OpenSSH.
+const (
+	_sshSigMagic     = "SSHSIG"
+	_sshSigVersion   = 1
+	_sshSigNamespace = "git"
+	_sshSigHash      = "sha512"
+	_sshSigBegin     = "-----BEGIN SSH SIGNATURE-----"
+	_sshSigEnd       = "-----END SSH SIGNATURE-----"
+	// _sshSigLineLen is a line length of the armored signature.
+	_sshSigLineLen = 70
+)
+
+// sshSigBlob is a SSH signature blob (after magic preamble).
+type sshSigBlob struct {
+	Version   uint32
+	PublicKey []byte
+	Namespace string
+	Reserved  string
+	HashAlg   string
+	Signature []byte
+}
+
+// sshSigner signs git objects with SSH key.
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+// Sign returns armored SSH signature of the message.
+func (s sshSigner) Sign(message io.Reader) ([]byte, error) {
+	h := sha512.New()
+
+	if _, err := io.Copy(h, message); err != nil {
+		return nil, err
+	}
+
+	data := sshSignedData(_sshSigNamespace, _sshSigHash, h.Sum(nil))
+
+	var (
+		sig *ssh.Signature
+		err error
+	)
+
+	// RSA keys are signed with SHA-512, ssh-rsa (SHA-1) signatures are rejected by git.
+	if as, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		sig, err = as.SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("ssh sign error: %w", err)
+	}
+
+	blob := append([]byte(_sshSigMagic), ssh.Marshal(sshSigBlob{
+		Version:   _sshSigVersion,
+		PublicKey: s.signer.PublicKey().Marshal(),
+		Namespace: _sshSigNamespace,
+		HashAlg:   _sshSigHash,
+		Signature: ssh.Marshal(sig),
+	})...)
+
+	return armorSSHSig(blob), nil
+}
+
+// verifySSHSig verifies armored SSH signature of the message and returns the signature public key.
+func verifySSHSig(message, armored []byte) (ssh.PublicKey, error) {
+	blob, err := unarmorSSHSig(armored)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(blob, []byte(_sshSigMagic)) {
+		return nil, errors.New("invalid ssh signature magic")
+	}
+
+	var b sshSigBlob
+
+	if err := ssh.Unmarshal(blob[len(_sshSigMagic):], &b); err != nil {
+		return nil, fmt.Errorf("invalid ssh signature: %w", err)
+	}
+
+	if b.Version != _sshSigVersion {
+		return nil, fmt.Errorf("unsupported ssh signature version %d", b.Version)
+	}
+
+	if b.Namespace != _sshSigNamespace {
+		return nil, fmt.Errorf("invalid ssh signature namespace %s", b.Namespace)
+	}
+
+	var h hash.Hash
+
+	switch b.HashAlg {
+	case "sha512":
+		h = sha512.New()
+	case "sha256":
+		h = sha256.New()
+	default:
+		return nil, fmt.Errorf("unsupported ssh signature hash %s", b.HashAlg)
+	}
+
+	h.Write(message)
+
+	pub, err := ssh.ParsePublicKey(b.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh signature key: %w", err)
+	}
+
+	sig := &ssh.Signature{}
+
+	if err := ssh.Unmarshal(b.Signature, sig); err != nil {
+		return nil, fmt.Errorf("invalid ssh signature: %w", err)
+	}
+
+	if err := pub.Verify(sshSignedData(b.Namespace, b.HashAlg, h.Sum(nil)), sig); err != nil {
+		return nil, fmt.Errorf("ssh signature verification error: %w", err)
+	}
+
+	return pub, nil
+}
+
+// sshSignedData returns data, that is signed by SSH key.
+func sshSignedData(namespace, hashAlg string, sum []byte) []byte {
+	return append([]byte(_sshSigMagic), ssh.Marshal(struct {
+		Namespace string
+		Reserved  string
+		HashAlg   string
+		Hash      []byte
+	}{
+		Namespace: namespace,
+		HashAlg:   hashAlg,
+		Hash:      sum,
+	})...)
+}
+
+// armorSSHSig returns armored SSH signature blob.
+func armorSSHSig(blob []byte) []byte {
+	enc := base64.StdEncoding.EncodeToString(blob)
+
+	var b bytes.Buffer
+
+	b.WriteString(_sshSigBegin + "\n")
+
+	for len(enc) > _sshSigLineLen {
+		b.WriteString(enc[:_sshSigLineLen] + "\n")
+		enc = enc[_sshSigLineLen:]
+	}
+
+	b.WriteString(enc + "\n")
+	b.WriteString(_sshSigEnd + "\n")
+
+	return b.Bytes()
+}
+
+// unarmorSSHSig returns SSH signature blob from armored signature.
+func unarmorSSHSig(armored []byte) ([]byte, error) {
+	s := strings.TrimSpace(string(armored))
+
+	body, ok := strings.CutPrefix(s, _sshSigBegin)
+	if !ok {
+		return nil, errors.New("invalid ssh signature armor")
+	}
+
+	body, ok = strings.CutSuffix(body, _sshSigEnd)
+	if !ok {
+		return nil, errors.New("invalid ssh signature armor")
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh signature: %w", err)
+	}
+
+	return blob, nil
+}
@@ -0,0 +1,101 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+)
+
+// _tagVersionMarker is a placeholder for version in tag template execution.
+const _tagVersionMarker = "\x00version\x00"
+
+// _defaultTagPrefix is a default tag prefix (v1.2.3).
+const _defaultTagPrefix = "v"
+
+// TagFormat is a git tag name format: prefix + version + suffix.
+type TagFormat struct {
+	prefix string
+	suffix string
+}
+
+// NewTagFormat returns a new TagFormat.
+// If tpl is not empty, then prefix is ignored and tag name is calculated from template with {{.Version}} variable.
+// Examples:
+//   - prefix "v": v1.2.3;
+//   - tpl "release-{{.Version}}": release-1.2.3;
+//   - tpl "api/v{{.Version}}": api/v1.2.3.
+func NewTagFormat(prefix, tpl string) (TagFormat, error) {
+	if tpl == "" {
+		return TagFormat{prefix: prefix}, nil
+	}
+
+	t, err := template.New("tag").Parse(tpl)
+	if err != nil {
+		return TagFormat{}, fmt.Errorf("parse tag template %s error: %w", tpl, err)
+	}
+
+	var b strings.Builder
+
+	if err := t.Execute(&b, struct{ Version string }{Version: _tagVersionMarker}); err != nil {
+		return TagFormat{}, fmt.Errorf("execute tag template %s error: %w", tpl, err)
+	}
+
+	prefix, suffix, ok := strings.Cut(b.String(), _tagVersionMarker)
+	if !ok || strings.Contains(suffix, _tagVersionMarker) {
+		return TagFormat{}, fmt.Errorf("tag template %s must contain {{.Version}} once", tpl)
+	}
+
+	return TagFormat{prefix: prefix, suffix: suffix}, nil
+}
+
+// tagFormat returns TagFormat from config (git.tagPrefix and git.tagTemplate).
+// If config is not set or invalid, then returns default format (config is validated on start).
+func tagFormat() TagFormat {
+	prefix := _defaultTagPrefix
+
+	if viper.IsSet(key.GitTagPrefix) {
+		prefix = viper.GetString(key.GitTagPrefix)
+	}
+
+	f, err := NewTagFormat(prefix, viper.GetString(key.GitTagTemplate))
+	if err != nil {
+		return TagFormat{prefix: _defaultTagPrefix}
+	}
+
+	return f
+}
+
+// TagName returns a git tag name for version by config format.
+func TagName(v version.V) string {
+	return tagFormat().Name(v)
+}
+
+// Name returns a tag name for version.
+func (f TagFormat) Name(v version.V) string {
+	return f.prefix + v.FormatString() + f.suffix
+}
+
+// Parse returns a version from tag name.
+// Returns false, if the tag name does not match the format.
+func (f TagFormat) Parse(name string) (version.V, bool) {
+	s, ok := strings.CutPrefix(name, f.prefix)
+	if !ok {
+		return "", false
+	}
+
+	s, ok = strings.CutSuffix(s, f.suffix)
+	if !ok {
+		return "", false
+	}
+
+	v := version.V(s)
+	if v.Invalid() || v.FormatString() != s {
+		return "", false
+	}
+
+	return v, true
+}
@@ -0,0 +1,195 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/klimby/version/internal/config/key"
+	"github.com/klimby/version/pkg/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTagFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefix    string
+		tpl       string
+		v         version.V
+		want      string
+		assertion assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "prefix",
+			prefix:    "v",
+			v:         "1.2.3",
+			want:      "v1.2.3",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "empty prefix",
+			v:         "1.2.3-rc.1",
+			want:      "1.2.3-rc.1",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "template",
+			prefix:    "v",
+			tpl:       "release-{{.Version}}",
+			v:         "1.2.3",
+			want:      "release-1.2.3",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "template with suffix",
+			tpl:       "api/v{{.Version}}-stable",
+			v:         "1.2.3",
+			want:      "api/v1.2.3-stable",
+			assertion: assert.NoError,
+		},
+		{
+			name:      "template parse error",
+			tpl:       "release-{{.Version",
+			assertion: assert.Error,
+		},
+		{
+			name:      "template execute error",
+			tpl:       "release-{{.Unknown}}",
+			assertion: assert.Error,
+		},
+		{
+			name:      "template without version",
+			tpl:       "release",
+			assertion: assert.Error,
+		},
+		{
+			name:      "template with two versions",
+			tpl:       "{{.Version}}-{{.Version}}",
+			assertion: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewTagFormat(tt.prefix, tt.tpl)
+			if !tt.assertion(t, err, "NewTagFormat()") || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.want, f.Name(tt.v), "Name()")
+		})
+	}
+}
+
+func TestTagFormat_Parse(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      TagFormat
+		tag    string
+		want   version.V
+		wantOk bool
+	}{
+		{
+			name:   "prefix",
+			f:      TagFormat{prefix: "v"},
+			tag:    "v1.2.3",
+			want:   "1.2.3",
+			wantOk: true,
+		},
+		{
+			name:   "pre-release",
+			f:      TagFormat{prefix: "v"},
+			tag:    "v1.2.3-rc.1",
+			want:   "1.2.3-rc.1",
+			wantOk: true,
+		},
+		{
+			name: "without prefix",
+			f:    TagFormat{prefix: "v"},
+			tag:  "1.2.3",
+		},
+		{
+			name: "foreign prefix",
+			f:    TagFormat{prefix: "v"},
+			tag:  "version1.2.3",
+		},
+		{
+			name: "not full version",
+			f:    TagFormat{prefix: "v"},
+			tag:  "v1.2",
+		},
+		{
+			name: "not version",
+			f:    TagFormat{prefix: "v"},
+			tag:  "vnext",
+		},
+		{
+			name: "empty prefix with v",
+			f:    TagFormat{},
+			tag:  "v1.2.3",
+		},
+		{
+			name:   "prefix and suffix",
+			f:      TagFormat{prefix: "api/v", suffix: "-stable"},
+			tag:    "api/v1.2.3-stable",
+			want:   "1.2.3",
+			wantOk: true,
+		},
+		{
+			name: "other suffix",
+			f:    TagFormat{prefix: "api/v", suffix: "-stable"},
+			tag:  "api/v1.2.3-beta",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.f.Parse(tt.tag)
+
+			assert.Equal(t, tt.wantOk, ok, "Parse() ok")
+			assert.Equal(t, tt.want, got, "Parse()")
+		})
+	}
+}
+
+func TestRepository_tags_bare(t *testing.T) {
+	viper.Set(key.DryRun, false)
+
+	defer viper.Reset()
+
+	repo, _, _, first := __releaseRepo(t)
+
+	_, err := repo.CreateTag("0.9.0", first, nil)
+	assert.NoError(t, err)
+
+	var warnings []string
+
+	r, err := NewRepository(func(options *RepoOptions) {
+		options.Repo = repo
+		options.Warn = func(s string) {
+			warnings = append(warnings, s)
+		}
+	})
+	assert.NoError(t, err)
+
+	tags, err := r.tags(tagFormat())
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2, "tags() with prefix")
+	assert.Empty(t, warnings, "tags() with prefix")
+
+	assert.NoError(t, repo.DeleteTag("v1.0.0"))
+	assert.NoError(t, repo.DeleteTag("v1.1.0"))
+
+	tags, err = r.tags(tagFormat())
+	assert.NoError(t, err)
+	assert.Empty(t, tags, "tags() without prefix")
+	assert.Len(t, warnings, 1, "tags() without prefix")
+	assert.Contains(t, warnings[0], `git.tagPrefix: ""`)
+
+	_, err = r.tags(tagFormat())
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1, "warning is printed once")
+
+	viper.Set(key.GitTagPrefix, "")
+
+	tags, err = r.tags(tagFormat())
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1, "tags() with empty prefix")
+}
@@ -56,11 +56,11 @@ func (v V) FormatString() string {
 	if prerelease != "" {
 		b.WriteString("-")
 		b.WriteString(prerelease)
+	}
 
-		if buildmetadata != "" {
-			b.WriteString("+")
-			b.WriteString(buildmetadata)
-		}
+	if buildmetadata != "" {
+		b.WriteString("+")
+		b.WriteString(buildmetadata)
 	}
 
 	return b.String()
@@ -76,28 +76,99 @@ func (v V) Invalid() bool {
 }
 
 // NextMajor returns the next major version.
+// For pre-release version X.0.0-pre returns X.0.0 (release of the pre-release).
 func (v V) NextMajor() V {
-	//nolint:dogsled
-	major, _, _, _, _ := v.semver()
+	major, minor, patch, prerelease, _ := v.semver()
+
+	if prerelease != "" && minor == 0 && patch == 0 {
+		return v.Release()
+	}
 
 	return V(convert.I2S(major+1) + ".0.0")
 }
 
 // NextMinor returns the next minor version.
+// For pre-release version X.Y.0-pre returns X.Y.0 (release of the pre-release).
 func (v V) NextMinor() V {
-	//nolint:dogsled
-	major, minor, _, _, _ := v.semver()
+	major, minor, patch, prerelease, _ := v.semver()
+
+	if prerelease != "" && patch == 0 {
+		return v.Release()
+	}
 
 	return V(convert.I2S(major) + "." + convert.I2S(minor+1) + ".0")
 }
 
 // NextPatch returns the next patch version.
+// For pre-release version X.Y.Z-pre returns X.Y.Z (release of the pre-release).
 func (v V) NextPatch() V {
-	major, minor, patch, _, _ := v.semver()
+	major, minor, patch, prerelease, _ := v.semver()
+
+	if prerelease != "" {
+		return v.Release()
+	}
 
 	return V(convert.I2S(major) + "." + convert.I2S(minor) + "." + convert.I2S(patch+1))
 }
 
+// NextPrerelease returns the next pre-release version with identifier pre.
+// If the version is a pre-release with the same identifier, then its number is incremented:
+// 1.3.0-rc.1 -> 1.3.0-rc.2. Else returns the version with pre-release pre.1: 1.3.0 -> 1.3.0-rc.1.
+func (v V) NextPrerelease(pre string) V {
+	prerelease := v.Prerelease()
+	release := v.Release().FormatString()
+
+	if num, ok := strings.CutPrefix(prerelease, pre+"."); ok {
+		if n := convert.S2Int(num, -1); n >= 0 {
+			return V(release + "-" + pre + "." + convert.I2S(n+1))
+		}
+	}
+
+	return V(release + "-" + pre + ".1")
+}
+
+// Prerelease returns the pre-release part of the version (rc.1 for 1.2.3-rc.1).
+func (v V) Prerelease() string {
+	_, _, _, prerelease, _ := v.semver()
+
+	return prerelease
+}
+
+// IsPrerelease returns true if the version has a pre-release part.
+func (v V) IsPrerelease() bool {
+	return v.Prerelease() != ""
+}
+
+// Build returns the build metadata part of the version (sha.abc for 1.2.3+sha.abc).
+func (v V) Build() string {
+	_, _, _, _, buildmetadata := v.semver()
+
+	return buildmetadata
+}
+
+// WithBuild returns the version with build metadata meta: 1.2.3 -> 1.2.3+meta.
+// Existing build metadata is replaced. If meta is empty, then build metadata is removed.
+func (v V) WithBuild(meta string) V {
+	release := v.Release().FormatString()
+
+	if pre := v.Prerelease(); pre != "" {
+		release += "-" + pre
+	}
+
+	if meta == "" {
+		return V(release)
+	}
+
+	return V(release + "+" + meta)
+}
+
+// Release returns the version without pre-release and build metadata (1.2.3 for 1.2.3-rc.1+build).
+func (v V) Release() V {
+	major, minor, patch, _, _ := v.semver()
+
+	return V(convert.I2S(major) + "." + convert.I2S(minor) + "." + convert.I2S(patch))
+}
+
 // Start returns the start version.
 func (V) Start() V {
 	return V("0.0.0")
@@ -175,8 +246,8 @@ func (v V) Compare(o V) int {
 		return cmp.Compare[string](v.String(), o.String())
 	}
 
-	vMajor, vMinor, vPatch, vPrerelease, vBuildmetadata := v.semver()
-	oMajor, oMinor, oPatch, oPrerelease, oBuildmetadata := o.semver()
+	vMajor, vMinor, vPatch, vPrerelease, _ := v.semver()
+	oMajor, oMinor, oPatch, oPrerelease, _ := o.semver()
 
 	// compare major
 	c := cmp.Compare[int](vMajor, oMajor)
@@ -196,14 +267,8 @@ func (v V) Compare(o V) int {
 		return c
 	}
 
-	// compare prerelease
-	c = comparePart(vPrerelease, oPrerelease)
-	if c != 0 {
-		return c
-	}
-
-	// compare buildmetadata
-	return comparePart(vBuildmetadata, oBuildmetadata)
+	// compare prerelease (build metadata does not figure into precedence)
+	return comparePart(vPrerelease, oPrerelease)
 }
 
 // LessThen returns true if the version is less then the argument.
@@ -250,7 +315,7 @@ func CompareDESC[T HasVersion](v, o T) int {
 	}
 }
 
-// CompareStrings compares two strings (build or prerelease).
+// comparePart compares two prerelease strings.
 //
 // Returns:
 //   - 0: equal;
@@ -296,7 +361,7 @@ func comparePart(v, o string) int {
 	}
 }
 
-// comparePartElement compares two strings (part of build or prerelease).
+// comparePartElement compares two strings (part of prerelease).
 func comparePartElement(v, o string) int {
 	vIsNum := reNum.MatchString(v)
 	oIsNum := reNum.MatchString(o)
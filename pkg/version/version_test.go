@@ -118,9 +118,21 @@ func TestV_Compare(t *testing.T) {
 			want:   -1,
 		},
 		{
-			name:   "1.0.0-beta.2+gamma < 1.0.0-beta.2",
+			name:   "1.0.0-beta.2+gamma = 1.0.0-beta.2",
 			first:  V("1.0.0-beta.2+gamma"),
 			second: V("1.0.0-beta.2"),
+			want:   0,
+		},
+		{
+			name:   "1.0.0+sha.abc = 1.0.0+sha.def",
+			first:  V("1.0.0+sha.abc"),
+			second: V("1.0.0+sha.def"),
+			want:   0,
+		},
+		{
+			name:   "1.0.0+sha.abc < 1.0.1",
+			first:  V("1.0.0+sha.abc"),
+			second: V("1.0.1"),
 			want:   -1,
 		},
 	}
@@ -596,6 +608,11 @@ func TestV_FormatString(t *testing.T) {
 			v:    "1.0.0-alpha+beta",
 			want: "1.0.0-alpha+beta",
 		},
+		{
+			name: "valid - build without prerelease",
+			v:    "1.2.3+sha.abc",
+			want: "1.2.3+sha.abc",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -622,6 +639,16 @@ func TestV_NextMajor(t *testing.T) {
 			v:    "1.0.0",
 			want: "2.0.0",
 		},
+		{
+			name: "major pre-release",
+			v:    "2.0.0-rc.1",
+			want: "2.0.0",
+		},
+		{
+			name: "minor pre-release",
+			v:    "1.1.0-rc.1",
+			want: "2.0.0",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -648,6 +675,16 @@ func TestV_NextMinor(t *testing.T) {
 			v:    "1.0.0",
 			want: "1.1.0",
 		},
+		{
+			name: "minor pre-release",
+			v:    "1.1.0-rc.1",
+			want: "1.1.0",
+		},
+		{
+			name: "patch pre-release",
+			v:    "1.1.1-rc.1",
+			want: "1.2.0",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -674,6 +711,11 @@ func TestV_NextPatch(t *testing.T) {
 			v:    "1.0.0",
 			want: "1.0.1",
 		},
+		{
+			name: "pre-release",
+			v:    "1.0.1-beta.2",
+			want: "1.0.1",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -684,6 +726,146 @@ func TestV_NextPatch(t *testing.T) {
 	}
 }
 
+func TestV_NextPrerelease(t *testing.T) {
+	tests := []struct {
+		name string
+		v    V
+		pre  string
+		want V
+	}{
+		{
+			name: "release",
+			v:    "1.3.0",
+			pre:  "rc",
+			want: "1.3.0-rc.1",
+		},
+		{
+			name: "same identifier",
+			v:    "1.3.0-rc.1",
+			pre:  "rc",
+			want: "1.3.0-rc.2",
+		},
+		{
+			name: "same identifier without number",
+			v:    "1.3.0-rc",
+			pre:  "rc",
+			want: "1.3.0-rc.1",
+		},
+		{
+			name: "other identifier",
+			v:    "1.3.0-beta.3",
+			pre:  "rc",
+			want: "1.3.0-rc.1",
+		},
+		{
+			name: "identifier with prefix",
+			v:    "1.3.0-rc2.1",
+			pre:  "rc",
+			want: "1.3.0-rc.1",
+		},
+		{
+			name: "build metadata",
+			v:    "1.3.0-rc.9+build",
+			pre:  "rc",
+			want: "1.3.0-rc.10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.NextPrerelease(tt.pre); got != tt.want {
+				t.Errorf("NextPrerelease() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestV_WithBuild(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         V
+		meta      string
+		want      V
+		wantBuild string
+	}{
+		{
+			name:      "add build",
+			v:         "v1.2.3",
+			meta:      "sha.abc",
+			want:      "1.2.3+sha.abc",
+			wantBuild: "sha.abc",
+		},
+		{
+			name:      "add build to pre-release",
+			v:         "1.2.3-rc.1",
+			meta:      "20240102",
+			want:      "1.2.3-rc.1+20240102",
+			wantBuild: "20240102",
+		},
+		{
+			name:      "replace build",
+			v:         "1.2.3+old",
+			meta:      "new",
+			want:      "1.2.3+new",
+			wantBuild: "new",
+		},
+		{
+			name: "remove build",
+			v:    "1.2.3-rc.1+old",
+			want: "1.2.3-rc.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.v.WithBuild(tt.meta)
+			if got != tt.want {
+				t.Errorf("WithBuild() = %v, want %v", got, tt.want)
+			}
+
+			if b := got.Build(); b != tt.wantBuild {
+				t.Errorf("Build() = %v, want %v", b, tt.wantBuild)
+			}
+		})
+	}
+}
+
+func TestV_Release(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         V
+		want      V
+		wantPre   string
+		wantIsPre bool
+	}{
+		{
+			name: "release",
+			v:    "v1.3.0",
+			want: "1.3.0",
+		},
+		{
+			name:      "pre-release",
+			v:         "1.3.0-rc.1+build",
+			want:      "1.3.0",
+			wantPre:   "rc.1",
+			wantIsPre: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.Release(); got != tt.want {
+				t.Errorf("Release() = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.v.Prerelease(); got != tt.wantPre {
+				t.Errorf("Prerelease() = %v, want %v", got, tt.wantPre)
+			}
+
+			if got := tt.v.IsPrerelease(); got != tt.wantIsPre {
+				t.Errorf("IsPrerelease() = %v, want %v", got, tt.wantIsPre)
+			}
+		})
+	}
+}
+
 func TestV_Start(t *testing.T) {
 	tests := []struct {
 		name string
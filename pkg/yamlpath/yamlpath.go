@@ -0,0 +1,95 @@
+// Package yamlpath provides search of YAML nodes by dotted key paths.
+package yamlpath
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Decode decodes all YAML documents from r.
+func Decode(r io.Reader) ([]*yaml.Node, error) {
+	dec := yaml.NewDecoder(r)
+
+	var docs []*yaml.Node
+
+	for {
+		doc := &yaml.Node{}
+
+		if err := dec.Decode(doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+}
+
+// Find returns node by dotted key path, for example info.version.
+// Numeric path segment is used as index for sequences, for example servers.0.url.
+// Node may be a document node or any other node.
+func Find(node *yaml.Node, path string) (*yaml.Node, bool) {
+	if node == nil || path == "" {
+		return nil, false
+	}
+
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil, false
+		}
+
+		node = node.Content[0]
+	}
+
+	for _, k := range strings.Split(path, ".") {
+		next, ok := child(node, k)
+		if !ok {
+			return nil, false
+		}
+
+		node = next
+	}
+
+	return node, true
+}
+
+// Scalars returns scalar nodes by dotted key paths from all documents.
+// Paths, that are not found or not scalars, are skipped.
+func Scalars(docs []*yaml.Node, paths ...string) []*yaml.Node {
+	var nodes []*yaml.Node
+
+	for _, doc := range docs {
+		for _, p := range paths {
+			if n, ok := Find(doc, p); ok && n.Kind == yaml.ScalarNode {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// child returns child node by key (mappings) or index (sequences).
+func child(node *yaml.Node, k string) (*yaml.Node, bool) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == k {
+				return node.Content[i+1], true
+			}
+		}
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(k)
+		if err == nil && i >= 0 && i < len(node.Content) {
+			return node.Content[i], true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,72 @@
+package yamlpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const __doc = `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0 # api version
+servers:
+  - url: http://localhost
+---
+version: 2.0.0
+`
+
+func TestFind(t *testing.T) {
+	docs, err := Decode(strings.NewReader(__doc))
+	if !assert.NoError(t, err) || !assert.Len(t, docs, 2) {
+		return
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		want   string
+		wantOk bool
+	}{
+		{name: "nested", path: "info.version", want: "1.0.0", wantOk: true},
+		{name: "sequence", path: "servers.0.url", want: "http://localhost", wantOk: true},
+		{name: "sequence out of range", path: "servers.1.url"},
+		{name: "not found", path: "info.name"},
+		{name: "empty", path: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Find(docs[0], tt.path)
+
+			assert.Equal(t, tt.wantOk, ok, "Find() ok")
+
+			if tt.wantOk {
+				assert.Equal(t, tt.want, got.Value, "Find() value")
+			}
+		})
+	}
+}
+
+func TestScalars(t *testing.T) {
+	docs, err := Decode(strings.NewReader(__doc))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	got := Scalars(docs, "version", "info.version", "info")
+
+	if !assert.Len(t, got, 2) {
+		return
+	}
+
+	assert.Equal(t, 4, got[0].Line, "info.version line")
+	assert.Equal(t, 8, got[1].Line, "version line")
+}
+
+func TestDecode_error(t *testing.T) {
+	_, err := Decode(strings.NewReader("a: [b"))
+
+	assert.Error(t, err)
+}